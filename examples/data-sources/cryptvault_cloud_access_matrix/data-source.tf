@@ -0,0 +1,15 @@
+data "cryptvault_cloud_access_matrix" "review" {
+  private_key = data.cryptvault_cloud_identity.operator.private_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  pattern     = "VALUES.app.>"
+}
+
+resource "local_file" "access_review_csv" {
+  filename = "${path.module}/access_review.csv"
+  content  = data.cryptvault_cloud_access_matrix.review.csv
+}
+
+resource "local_file" "access_review_md" {
+  filename = "${path.module}/access_review.md"
+  content  = data.cryptvault_cloud_access_matrix.review.markdown
+}
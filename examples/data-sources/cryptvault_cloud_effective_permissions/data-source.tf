@@ -0,0 +1,11 @@
+data "cryptvault_cloud_effective_permissions" "app" {
+  identity_id = cryptvault_cloud_identity.app.id
+  name        = "VALUES.app.prod.>"
+}
+
+check "app_can_read_its_values" {
+  assert {
+    condition     = data.cryptvault_cloud_effective_permissions.app.read
+    error_message = "The app identity can not read VALUES.app.prod.>"
+  }
+}
@@ -0,0 +1,7 @@
+data "cryptvault_cloud_identities" "ci" {
+  private_key   = data.cryptvault_cloud_identity.operator.private_key
+  vault_id      = data.cryptvault_cloud_vault.my_vault.id
+  name_regex    = "^ci-"
+  rights_target = "values"
+  rights_prefix = "VALUES.app"
+}
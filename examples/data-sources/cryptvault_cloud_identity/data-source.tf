@@ -2,3 +2,9 @@ data "cryptvault_cloud_identity" "operator" {
   private_key = "long_long_private_key"
   vault_id    = data.cryptvault_cloud_vault.my_vault.id
 }
+
+data "cryptvault_cloud_identity" "other_team" {
+  private_key = data.cryptvault_cloud_identity.operator.private_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  name        = "other_team_ci"
+}
@@ -0,0 +1,18 @@
+data "cryptvault_cloud_value_names" "app" {
+  creator_key = data.cryptvault_cloud_identity.operator.private_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  pattern     = "VALUES.app.>"
+}
+
+data "cryptvault_cloud_least_privilege" "worker" {
+  read         = ["VALUES.app.prod.db.user", "VALUES.app.prod.db.password", "VALUES.app.prod.api.key"]
+  write        = ["VALUES.app.prod.api.key"]
+  known_values = [for v in data.cryptvault_cloud_value_names.app.values : v.name]
+}
+
+resource "cryptvault_cloud_identity" "worker" {
+  creator_key = data.cryptvault_cloud_identity.operator.private_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  name        = "worker"
+  rights      = data.cryptvault_cloud_least_privilege.worker.rights
+}
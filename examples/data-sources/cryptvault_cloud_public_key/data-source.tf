@@ -1,3 +1,12 @@
 data "cryptvault_cloud_public_key" "identity_pub_key" {
   public_key = "public key string"
 }
+
+data "cryptvault_cloud_public_key" "partner" {
+  public_key_pem = file("${path.module}/partner.pub.pem")
+  vault_id       = cryptvault_cloud_vault.my_vault.id
+}
+
+output "partner_identity_id" {
+  value = data.cryptvault_cloud_public_key.partner.identity_id
+}
@@ -0,0 +1,8 @@
+data "cryptvault_cloud_rights_explain" "writer" {
+  patterns = [for r in cryptvault_cloud_identity.writer.rights : r.right_value_pattern]
+}
+
+# (rwd)VALUES.some.path.> grants read, write, delete on all values below VALUES.some.path (one or more groups deeper)
+output "writer_rights" {
+  value = data.cryptvault_cloud_rights_explain.writer.explanation
+}
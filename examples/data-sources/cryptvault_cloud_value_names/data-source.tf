@@ -0,0 +1,5 @@
+data "cryptvault_cloud_value_names" "app" {
+  creator_key = data.cryptvault_cloud_identity.operator.private_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  pattern     = "VALUES.app.>"
+}
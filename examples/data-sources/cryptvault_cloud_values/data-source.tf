@@ -0,0 +1,6 @@
+data "cryptvault_cloud_values" "app_prod" {
+  creator_key  = data.cryptvault_cloud_identity.operator.private_key
+  vault_id     = data.cryptvault_cloud_vault.my_vault.id
+  pattern      = "VALUES.app.prod.>"
+  strip_prefix = true
+}
@@ -0,0 +1,25 @@
+ephemeral "cryptvault_cloud_keypair" "ci" {}
+
+resource "cryptvault_cloud_identity" "ci" {
+  name                  = "ci"
+  vault_id              = cryptvault_cloud_vault.my_vault.id
+  creator_key           = cryptvault_cloud_vault.my_vault.operator_private_key
+  public_key_wo         = ephemeral.cryptvault_cloud_keypair.ci.public_key
+  public_key_wo_version = 1
+  rights = [
+    {
+      right_value_pattern = "(r)VALUES.ci.>"
+    }
+  ]
+}
+
+# the private key only lands in the write-only argument of the other system
+resource "kubernetes_secret_v1" "ci" {
+  metadata {
+    name = "cryptvault-ci"
+  }
+  data_wo = {
+    private_key = ephemeral.cryptvault_cloud_keypair.ci.private_key
+  }
+  data_wo_revision = 1
+}
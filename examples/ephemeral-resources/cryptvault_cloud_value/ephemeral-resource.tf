@@ -0,0 +1,12 @@
+ephemeral "cryptvault_cloud_value" "db_password" {
+  creator_key = var.reader_key
+  vault_id    = data.cryptvault_cloud_vault.my_vault.id
+  name        = "VALUES.some.path.db.password"
+}
+
+# hand the passframe to a write-only argument of another provider
+resource "aws_db_instance" "example" {
+  # ...
+  password_wo         = ephemeral.cryptvault_cloud_value.db_password.passframe
+  password_wo_version = 1
+}
@@ -0,0 +1,4 @@
+output "bootstrap_token" {
+  value     = provider::cryptvault::decrypt_with(cryptvault_cloud_keypair.vm.private_key, cryptvault_cloud_encrypted_payload.bootstrap.ciphertext)
+  sensitive = true
+}
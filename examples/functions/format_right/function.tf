@@ -0,0 +1,7 @@
+locals {
+  # (r)VALUES.foo.>
+  read_only = provider::cryptvault::format_right(merge(provider::cryptvault::parse_right("(rwd)VALUES.foo.>"), {
+    write  = false
+    delete = false
+  }))
+}
@@ -0,0 +1,3 @@
+output "partner_identity_id" {
+  value = provider::cryptvault::identity_id(file("${path.module}/partner.pub.pem"), cryptvault_cloud_vault.my_vault.id)
+}
@@ -0,0 +1,4 @@
+locals {
+  # { target = "values", read = true, write = true, delete = false, path = "VALUES.foo.>" }
+  right = provider::cryptvault::parse_right("(rw)VALUES.foo.>")
+}
@@ -0,0 +1,18 @@
+variable "reader_rights" {
+  default = ["(r)VALUES.app.prod.>"]
+}
+
+resource "cryptvault_cloud_value" "db_password" {
+  vault_id    = cryptvault_cloud_vault.my_vault.id
+  name        = "VALUES.app.prod.db.password"
+  passframe   = var.db_password
+  type        = "String"
+  creator_key = cryptvault_cloud_identity.writer.private_key
+
+  lifecycle {
+    precondition {
+      condition     = anytrue([for r in var.reader_rights : provider::cryptvault::path_matches(r, "VALUES.app.prod.db.password")])
+      error_message = "The service identity can not read this value."
+    }
+  }
+}
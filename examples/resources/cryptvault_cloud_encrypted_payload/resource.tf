@@ -0,0 +1,12 @@
+resource "cryptvault_cloud_keypair" "vm" {}
+
+resource "cryptvault_cloud_encrypted_payload" "bootstrap" {
+  public_key        = cryptvault_cloud_keypair.vm.public_key
+  plaintext_wo      = var.bootstrap_token
+  plaintext_version = 1
+}
+
+resource "aws_instance" "vm" {
+  # ...
+  user_data = cryptvault_cloud_encrypted_payload.bootstrap.ciphertext
+}
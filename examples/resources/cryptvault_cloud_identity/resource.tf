@@ -24,3 +24,26 @@ resource "cryptvault_cloud_identity" "value1-reader" {
     }
   ]
 }
+
+resource "cryptvault_cloud_role" "app_reader" {
+  name = "app_reader"
+  rights = [
+    {
+      right_value_pattern = "(r)VALUES.app.>"
+    }
+  ]
+}
+
+# effective rights: (r)VALUES.app.> and (w)VALUES.app.worker.>
+resource "cryptvault_cloud_identity" "worker" {
+  name        = "worker"
+  vault_id    = cryptvault_cloud_vault.my_vault.id
+  creator_key = cryptvault_cloud_vault.my_vault.operator_private_key
+  public_key  = cryptvault_cloud_keypair.worker.public_key
+  roles       = [cryptvault_cloud_role.app_reader]
+  rights = [
+    {
+      right_value_pattern = "(w)VALUES.app.worker.>"
+    }
+  ]
+}
@@ -0,0 +1,3 @@
+# Import an existing P521 keypair by its public key (base64 format of the project),
+# the private key has to be set by private_key or private_key_pem in the configuration
+terraform import cryptvault_cloud_keypair.laptop "$(cat laptop.pub.b64)"
@@ -1 +1,6 @@
 resource "cryptvault_cloud_keypair" "value1-reader" {}
+
+# reuse an existing P521 key
+resource "cryptvault_cloud_keypair" "laptop" {
+  private_key_pem = file("${path.module}/laptop.pem")
+}
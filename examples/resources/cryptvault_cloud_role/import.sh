@@ -0,0 +1,2 @@
+# Import a role by its name, the rights are taken from the config with the next apply
+terraform import cryptvault_cloud_role.app_reader app_reader
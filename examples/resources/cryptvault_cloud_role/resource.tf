@@ -0,0 +1,19 @@
+resource "cryptvault_cloud_role" "app_reader" {
+  name = "app_reader"
+  rights = [
+    {
+      right_value_pattern = "(r)VALUES.app.>"
+    },
+    {
+      right_value_pattern = "(r)IDENTITY.>"
+    }
+  ]
+}
+
+resource "cryptvault_cloud_identity" "reader" {
+  name        = "reader"
+  vault_id    = cryptvault_cloud_vault.my_vault.id
+  creator_key = cryptvault_cloud_vault.my_vault.operator_private_key
+  public_key  = cryptvault_cloud_keypair.reader.public_key
+  roles       = [cryptvault_cloud_role.app_reader]
+}
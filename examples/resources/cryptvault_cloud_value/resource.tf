@@ -18,4 +18,27 @@ resource "cryptvault_cloud_value" "value2" {
 
 }
 
+resource "cryptvault_cloud_value" "generated" {
+  vault_id    = cryptvault_cloud_vault.my_vault.id
+  name        = "VALUES.some.path.generated.name"
+  type        = "String"
+  creator_key = cryptvault_cloud_identity.writer.private_key
+  generate = {
+    length      = 24
+    min_special = 2
+    keepers = {
+      rotated_by = "ticket-123"
+    }
+  }
+  rotation_period = "720h"
+}
 
+# Terraform 1.11+: the passframe is never stored in plan or state
+resource "cryptvault_cloud_value" "write_only" {
+  vault_id          = cryptvault_cloud_vault.my_vault.id
+  name              = "VALUES.some.path.write_only.name"
+  type              = "String"
+  creator_key       = cryptvault_cloud_identity.writer.private_key
+  passframe_wo      = var.db_password
+  passframe_version = 1
+}
@@ -0,0 +1,16 @@
+resource "cryptvault_cloud_values" "app_prod" {
+  vault_id    = cryptvault_cloud_vault.my_vault.id
+  creator_key = cryptvault_cloud_identity.writer.private_key
+  prefix      = "VALUES.app.prod"
+  exclusive   = true
+  values = {
+    "db.user" = {
+      passframe = "app"
+      type      = "String"
+    }
+    "db.password" = {
+      passframe = "1234AVT"
+      type      = "String"
+    }
+  }
+}
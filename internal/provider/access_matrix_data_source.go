@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSourceWithConfigure = &AccessMatrixDataSource{}
+
+func NewAccessMatrixDataSource() datasource.DataSource {
+	return &AccessMatrixDataSource{}
+}
+
+type AccessMatrixDataSource struct {
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
+}
+
+type AccessMatrixDataSourceModel struct {
+	PrivateKey types.String                `tfsdk:"private_key"`
+	VaultID    types.String                `tfsdk:"vault_id"`
+	Pattern    types.String                `tfsdk:"pattern"`
+	Values     []types.String              `tfsdk:"values"`
+	Identities []AccessMatrixIdentityModel `tfsdk:"identities"`
+	CSV        types.String                `tfsdk:"csv"`
+	Markdown   types.String                `tfsdk:"markdown"`
+}
+
+type AccessMatrixIdentityModel struct {
+	Id     types.String              `tfsdk:"id"`
+	Name   types.String              `tfsdk:"name"`
+	Access []AccessMatrixAccessModel `tfsdk:"access"`
+}
+
+type AccessMatrixAccessModel struct {
+	Value  types.String `tfsdk:"value"`
+	Read   types.Bool   `tfsdk:"read"`
+	Write  types.Bool   `tfsdk:"write"`
+	Delete types.Bool   `tfsdk:"delete"`
+}
+
+func (d *AccessMatrixDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_access_matrix"
+}
+
+func (d *AccessMatrixDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Build an identity × value matrix of a vault: for each identity which values it can read, write or delete based on its rights.
+
+Only values visible to the reader identity are part of the matrix, so use an identity with read access to all values (f.e. the operator).
+Cells of csv and markdown are written as rwd, a - marks a missing direction f.e.: r-- only read.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of reader identity (default: private_key of provider)",
+				Description:         "Private Key of reader identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "Only values matching this pattern f.e.: VALUES.app.prod.> (default: VALUES.>)",
+				Description:         "Only values matching this pattern f.e.: VALUES.app.prod.> (default: VALUES.>)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(valueSelectorRegex, "Have to match "+valueSelectorRegexStr),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "Names of values in the matrix, the columns of csv and markdown",
+				Description:         "Names of values in the matrix, the columns of csv and markdown",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"identities": schema.ListNestedAttribute{
+				MarkdownDescription: "Identities of the vault with the values they have access to",
+				Description:         "Identities of the vault with the values they have access to",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "id of identity",
+							Description:         "id of identity",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of identity",
+							Description:         "Name of identity",
+							Computed:            true,
+						},
+						"access": schema.ListNestedAttribute{
+							MarkdownDescription: "Values with at least one granted direction",
+							Description:         "Values with at least one granted direction",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"value": schema.StringAttribute{
+										MarkdownDescription: "Name of value",
+										Description:         "Name of value",
+										Computed:            true,
+									},
+									"read": schema.BoolAttribute{
+										MarkdownDescription: "Identity can read the value",
+										Description:         "Identity can read the value",
+										Computed:            true,
+									},
+									"write": schema.BoolAttribute{
+										MarkdownDescription: "Identity can write the value",
+										Description:         "Identity can write the value",
+										Computed:            true,
+									},
+									"delete": schema.BoolAttribute{
+										MarkdownDescription: "Identity can delete the value",
+										Description:         "Identity can delete the value",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"csv": schema.StringAttribute{
+				MarkdownDescription: "Matrix as csv, one row for each identity and one column for each value",
+				Description:         "Matrix as csv, one row for each identity and one column for each value",
+				Computed:            true,
+			},
+			"markdown": schema.StringAttribute{
+				MarkdownDescription: "Matrix as markdown table, one row for each identity and one column for each value",
+				Description:         "Matrix as markdown table, one row for each identity and one column for each value",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AccessMatrixDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.provider = providerData
+}
+
+func (d *AccessMatrixDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccessMatrixDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, vaultID := d.provider.readerKey(data.PrivateKey, data.VaultID)
+	pApi, err := getProtectedApi(d.client, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	query, err := newVaultQuery(d.provider.Endpoint, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+
+	pattern := "VALUES.>"
+	if !data.Pattern.IsNull() {
+		pattern = data.Pattern.ValueString()
+	}
+	listed, err := listValuesByPattern(pApi, privateKey, vaultID, pattern)
+	if err != nil {
+		resp.Diagnostics.AddError("Not Possible to list values", err.Error())
+		return
+	}
+	names := make([]string, 0, len(listed))
+	for _, v := range listed {
+		names = append(names, v.Name)
+	}
+	sort.Strings(names)
+
+	// Identities pages through all identities of the vault, so none is missing in the report
+	identities, err := query.Identities(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Identities can not be fetched from API", err.Error())
+		return
+	}
+	sort.SliceStable(identities, func(i, j int) bool {
+		if identityDisplayName(identities[i]) != identityDisplayName(identities[j]) {
+			return identityDisplayName(identities[i]) < identityDisplayName(identities[j])
+		}
+		return identities[i].Id < identities[j].Id
+	})
+
+	header := append([]string{"identity_id", "identity_name"}, names...)
+	rows := make([][]string, 0, len(identities))
+	data.VaultID = vaultID
+	data.Values = make([]types.String, 0, len(names))
+	for _, name := range names {
+		data.Values = append(data.Values, types.StringValue(name))
+	}
+	data.Identities = make([]AccessMatrixIdentityModel, 0, len(identities))
+	for _, identity := range identities {
+		rights := make([]grantedRight, 0, len(identity.Rights))
+		for _, v := range identity.Rights {
+			rights = append(rights, grantedRight{Target: v.Target, Right: v.Right, Pattern: v.RightValuePattern})
+		}
+		item := AccessMatrixIdentityModel{
+			Id:     types.StringValue(identity.Id),
+			Name:   types.StringPointerValue(identity.Name),
+			Access: make([]AccessMatrixAccessModel, 0),
+		}
+		row := []string{identity.Id, identityDisplayName(identity)}
+		for _, name := range names {
+			read := len(matchingRights(rights, client.DirectionsRead, name)) > 0
+			write := len(matchingRights(rights, client.DirectionsWrite, name)) > 0
+			del := len(matchingRights(rights, client.DirectionsDelete, name)) > 0
+			row = append(row, accessCell(read, write, del))
+			if read || write || del {
+				item.Access = append(item.Access, AccessMatrixAccessModel{
+					Value:  types.StringValue(name),
+					Read:   types.BoolValue(read),
+					Write:  types.BoolValue(write),
+					Delete: types.BoolValue(del),
+				})
+			}
+		}
+		rows = append(rows, row)
+		data.Identities = append(data.Identities, item)
+	}
+
+	csvText, err := accessMatrixCSV(header, rows)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to write csv", err.Error())
+		return
+	}
+	data.CSV = types.StringValue(csvText)
+	data.Markdown = types.StringValue(accessMatrixMarkdown(header, rows))
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{"identities": len(identities), "values": len(names)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func identityDisplayName(identity *queriedIdentity) string {
+	if identity.Name == nil {
+		return ""
+	}
+	return *identity.Name
+}
+
+// accessCell returns the directions as rwd, a - marks a missing direction f.e.: r-- only read.
+func accessCell(read, write, del bool) string {
+	cell := []byte("---")
+	if read {
+		cell[0] = 'r'
+	}
+	if write {
+		cell[1] = 'w'
+	}
+	if del {
+		cell[2] = 'd'
+	}
+	return string(cell)
+}
+
+func accessMatrixCSV(header []string, rows [][]string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func accessMatrixMarkdown(header []string, rows [][]string) string {
+	var b strings.Builder
+	line := func(cells []string) {
+		escaped := make([]string, 0, len(cells))
+		for _, c := range cells {
+			escaped = append(escaped, strings.ReplaceAll(c, "|", "\\|"))
+		}
+		b.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	}
+	line(header)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	line(separator)
+	for _, row := range rows {
+		line(row)
+	}
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package provider
+
+import "testing"
+
+func TestAccessCell(t *testing.T) {
+	tests := []struct {
+		read, write, del bool
+		want             string
+	}{
+		{false, false, false, "---"},
+		{true, false, false, "r--"},
+		{false, true, false, "-w-"},
+		{false, false, true, "--d"},
+		{true, false, true, "r-d"},
+		{true, true, true, "rwd"},
+	}
+	for _, tt := range tests {
+		if got := accessCell(tt.read, tt.write, tt.del); got != tt.want {
+			t.Errorf("accessCell(%v, %v, %v) = %q, want %q", tt.read, tt.write, tt.del, got, tt.want)
+		}
+	}
+}
+
+func TestAccessMatrixText(t *testing.T) {
+	header := []string{"identity_id", "identity_name", "VALUES.a", "VALUES.b"}
+	tests := []struct {
+		name         string
+		rows         [][]string
+		wantCSV      string
+		wantMarkdown string
+	}{
+		{
+			name:         "no identities",
+			rows:         [][]string{},
+			wantCSV:      "identity_id,identity_name,VALUES.a,VALUES.b\n",
+			wantMarkdown: "| identity_id | identity_name | VALUES.a | VALUES.b |\n| --- | --- | --- | --- |\n",
+		},
+		{
+			name: "escaped names",
+			rows: [][]string{
+				{"id1", "ops|team", "rw-", "---"},
+				{"id2", "a, \"b\"", "r--", "r--"},
+			},
+			wantCSV: "identity_id,identity_name,VALUES.a,VALUES.b\n" +
+				"id1,ops|team,rw-,---\n" +
+				"id2,\"a, \"\"b\"\"\",r--,r--\n",
+			wantMarkdown: "| identity_id | identity_name | VALUES.a | VALUES.b |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| id1 | ops\\|team | rw- | --- |\n" +
+				"| id2 | a, \"b\" | r-- | r-- |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csv, err := accessMatrixCSV(header, tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if csv != tt.wantCSV {
+				t.Errorf("accessMatrixCSV() = %q, want %q", csv, tt.wantCSV)
+			}
+			if md := accessMatrixMarkdown(header, tt.rows); md != tt.wantMarkdown {
+				t.Errorf("accessMatrixMarkdown() = %q, want %q", md, tt.wantMarkdown)
+			}
+		})
+	}
+}
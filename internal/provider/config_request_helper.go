@@ -6,24 +6,52 @@ import (
 	client "github.com/cryptvault-cloud/api"
 	"github.com/cryptvault-cloud/helper"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 func getClientRessource(req *resource.ConfigureRequest) (client.ApiHandler, error) {
-	client, ok := req.ProviderData.(client.ApiHandler)
+	data, ok := req.ProviderData.(*VaultCloudProviderData)
 	if !ok {
-		return nil, errors.New("ProviderData is not client.ApiHandler")
+		return nil, errors.New("ProviderData is not *VaultCloudProviderData")
 	}
-	return client, nil
+	return data.Client, nil
 }
 
 func getClient(req *datasource.ConfigureRequest) (client.ApiHandler, error) {
-	client, ok := req.ProviderData.(client.ApiHandler)
+	data, err := getProviderData(req)
+	if err != nil {
+		return nil, err
+	}
+	return data.Client, nil
+}
+
+func getProviderData(req *datasource.ConfigureRequest) (*VaultCloudProviderData, error) {
+	data, ok := req.ProviderData.(*VaultCloudProviderData)
 	if !ok {
-		return nil, errors.New("ProviderData is not *client.Api")
+		return nil, errors.New("ProviderData is not *VaultCloudProviderData")
 	}
-	return client, nil
+	return data, nil
+}
+
+func getProviderDataEphemeral(req *ephemeral.ConfigureRequest) (*VaultCloudProviderData, error) {
+	data, ok := req.ProviderData.(*VaultCloudProviderData)
+	if !ok {
+		return nil, errors.New("ProviderData is not *VaultCloudProviderData")
+	}
+	return data, nil
+}
+
+// readerKey returns the given private key and vault id, or the provider wide defaults if they are not set.
+func (p *VaultCloudProviderData) readerKey(privateKey basetypes.StringValue, vaultID basetypes.StringValue) (basetypes.StringValue, basetypes.StringValue) {
+	if privateKey.IsNull() {
+		privateKey = p.PrivateKey
+	}
+	if vaultID.IsNull() {
+		vaultID = p.VaultID
+	}
+	return privateKey, vaultID
 }
 
 func getProtectedApi(api client.ApiHandler, privateKey basetypes.StringValue, vaultID basetypes.StringValue) (client.ProtectedApiHandler, error) {
@@ -40,3 +68,16 @@ func getProtectedApi(api client.ApiHandler, privateKey basetypes.StringValue, va
 	vault_id := vaultID.ValueString()
 	return api.GetProtectedApi(private_key, vault_id), nil
 }
+
+// getIdentityId returns the identity id belonging to the private key in the vault.
+func getIdentityId(privateKey basetypes.StringValue, vaultID basetypes.StringValue) (string, error) {
+	private_key, err := helper.GetPrivateKeyFromB64String(privateKey.ValueString())
+	if err != nil {
+		return "", errors.Join(errors.New("private key is not an ecdsa.Private key"), err)
+	}
+	pubKey, err := helper.NewBase64PublicPem(&private_key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return pubKey.GetIdentityId(vaultID.ValueString())
+}
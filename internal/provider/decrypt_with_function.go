@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &DecryptWithFunction{}
+
+func NewDecryptWithFunction() function.Function {
+	return &DecryptWithFunction{}
+}
+
+// DecryptWithFunction decrypts a ciphertext of cryptvault_cloud_encrypted_payload or the client library.
+type DecryptWithFunction struct{}
+
+func (f *DecryptWithFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decrypt_with"
+}
+
+func (f *DecryptWithFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decrypt a ciphertext with a private key",
+		MarkdownDescription: "Decrypt a base64 ciphertext encrypted for the public key of the private key, f.e. by cryptvault_cloud_encrypted_payload. It uses the same scheme as the client library.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "private_key",
+				MarkdownDescription: "Private key (base64 format of the project or PEM)",
+			},
+			function.StringParameter{
+				Name:                "ciphertext",
+				MarkdownDescription: "Base64 encoded ciphertext",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DecryptWithFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var privateKey, ciphertext string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &privateKey, &ciphertext))
+	if resp.Error != nil {
+		return
+	}
+
+	privKey, err := parsePrivateKey(privateKey)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Private key is invalid can not be decoded: "+err.Error())
+		return
+	}
+	plaintext, err := decryptPayload(privKey, ciphertext)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to decrypt ciphertext: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(plaintext)))
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSourceWithConfigure = &EffectivePermissionsDataSource{}
+
+func NewEffectivePermissionsDataSource() datasource.DataSource {
+	return &EffectivePermissionsDataSource{}
+}
+
+type EffectivePermissionsDataSource struct {
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
+}
+
+type EffectivePermissionsDataSourceModel struct {
+	PrivateKey    types.String             `tfsdk:"private_key"`
+	VaultID       types.String             `tfsdk:"vault_id"`
+	IdentityID    types.String             `tfsdk:"identity_id"`
+	PublicKey     types.String             `tfsdk:"public_key"`
+	Name          types.String             `tfsdk:"name"`
+	Read          types.Bool               `tfsdk:"read"`
+	Write         types.Bool               `tfsdk:"write"`
+	Delete        types.Bool               `tfsdk:"delete"`
+	MatchedRights []IdentityRightDataModel `tfsdk:"matched_rights"`
+}
+
+func (d *EffectivePermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_effective_permissions"
+}
+
+func (d *EffectivePermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Check which directions (read, write, delete) the rights of an identity grant on a value name or pattern.
+
+For a pattern f.e.: VALUES.app.* a direction is only granted if a right covers all names of the pattern.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of reader identity (default: private_key of provider)",
+				Description:         "Private Key of reader identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"identity_id": schema.StringAttribute{
+				MarkdownDescription: "id of checked identity, exactly one of identity_id or public_key have to be set",
+				Description:         "id of checked identity, exactly one of identity_id or public_key have to be set",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("public_key")),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public Key of checked identity",
+				Description:         "Public Key of checked identity",
+				Optional:            true,
+				Validators: []validator.String{
+					publicKeyValidator{},
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Value name f.e.: VALUES.app.db.password or pattern f.e.: VALUES.app.>",
+				Description:         "Value name f.e.: VALUES.app.db.password or pattern f.e.: VALUES.app.>",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(valueSelectorRegex, "Have to match "+valueSelectorRegexStr),
+				},
+			},
+			"read": schema.BoolAttribute{
+				MarkdownDescription: "Identity is allowed to read",
+				Description:         "Identity is allowed to read",
+				Computed:            true,
+			},
+			"write": schema.BoolAttribute{
+				MarkdownDescription: "Identity is allowed to write",
+				Description:         "Identity is allowed to write",
+				Computed:            true,
+			},
+			"delete": schema.BoolAttribute{
+				MarkdownDescription: "Identity is allowed to delete",
+				Description:         "Identity is allowed to delete",
+				Computed:            true,
+			},
+			"matched_rights": schema.ListNestedAttribute{
+				MarkdownDescription: "Rights of identity which grant a direction on name",
+				Description:         "Rights of identity which grant a direction on name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Target of right (values, identities, system)",
+							Description:         "Target of right (values, identities, system)",
+							Computed:            true,
+						},
+						"right": schema.StringAttribute{
+							MarkdownDescription: "Direction of right (read, write, delete)",
+							Description:         "Direction of right (read, write, delete)",
+							Computed:            true,
+						},
+						"right_value_pattern": schema.StringAttribute{
+							MarkdownDescription: "Pattern of right f.e.: VALUES.foo.>",
+							Description:         "Pattern of right f.e.: VALUES.foo.>",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EffectivePermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.provider = providerData
+}
+
+func (d *EffectivePermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectivePermissionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, vaultID := d.provider.readerKey(data.PrivateKey, data.VaultID)
+	pApi, err := getProtectedApi(d.client, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	data.VaultID = vaultID
+
+	if data.IdentityID.IsNull() {
+		pubKey, err := parsePublicKey(data.PublicKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Public key is invalid can not be decoded", err.Error())
+			return
+		}
+		b64pub, err := helper.NewBase64PublicPem(pubKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Public key is invalid can not be decoded", err.Error())
+			return
+		}
+		id, err := b64pub.GetIdentityId(vaultID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("unable to create id form public key", err.Error())
+			return
+		}
+		data.IdentityID = types.StringValue(id)
+	}
+
+	rights, err := identityRights(pApi, data.IdentityID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Identity can not be fetched from API", err.Error())
+		return
+	}
+
+	name := data.Name.ValueString()
+	data.Read = types.BoolValue(len(matchingRights(rights, client.DirectionsRead, name)) > 0)
+	data.Write = types.BoolValue(len(matchingRights(rights, client.DirectionsWrite, name)) > 0)
+	data.Delete = types.BoolValue(len(matchingRights(rights, client.DirectionsDelete, name)) > 0)
+	data.MatchedRights = make([]IdentityRightDataModel, 0)
+	for _, direction := range []client.Directions{client.DirectionsRead, client.DirectionsWrite, client.DirectionsDelete} {
+		for _, v := range matchingRights(rights, direction, name) {
+			data.MatchedRights = append(data.MatchedRights, IdentityRightDataModel{
+				Target:            types.StringValue(string(v.Target)),
+				Right:             types.StringValue(string(v.Right)),
+				RightValuePattern: types.StringValue(v.Pattern),
+			})
+		}
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
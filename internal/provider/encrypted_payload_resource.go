@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &EncryptedPayloadResource{}
+
+// EncryptedPayloadResource encrypts a payload for a public key locally, outside of the vault.
+// It is a resource and not a function, because the encryption is randomized and
+// the ciphertext have to stay stable between runs.
+type EncryptedPayloadResource struct {
+}
+
+type EncryptedPayloadResourceModel struct {
+	PublicKey        types.String `tfsdk:"public_key"`
+	Plaintext        types.String `tfsdk:"plaintext"`
+	PlaintextWo      types.String `tfsdk:"plaintext_wo"`
+	PlaintextVersion types.Int64  `tfsdk:"plaintext_version"`
+	Ciphertext       types.String `tfsdk:"ciphertext"`
+}
+
+func NewEncryptedPayloadResource() resource.Resource {
+	return &EncryptedPayloadResource{}
+}
+
+func (r *EncryptedPayloadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_encrypted_payload"
+}
+
+func (r *EncryptedPayloadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Encrypt a payload locally for the public key of an identity, with the same scheme the vault uses for values.
+Only the owner of the private key can decrypt it again, f.e. with the decrypt_with function or the client library.
+
+As an example: hand a bootstrap secret to a new machine over user-data.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key to encrypt for (base64 format of the project, SPKI PEM or JWK)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					publicKeyValidator{},
+				},
+			},
+			"plaintext": schema.StringAttribute{
+				MarkdownDescription: "Payload to encrypt, exactly one of plaintext or plaintext_wo have to be set",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("plaintext_wo")),
+				},
+			},
+			"plaintext_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only payload to encrypt (Terraform 1.11+), it is never stored in plan or state",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("plaintext_version")),
+				},
+			},
+			"plaintext_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of plaintext_wo, a change encrypts the current plaintext_wo again",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("plaintext_wo")),
+				},
+			},
+			"ciphertext": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded ciphertext",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *EncryptedPayloadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EncryptedPayloadResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plaintext := data.Plaintext
+	if !data.PlaintextVersion.IsNull() {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plaintext_wo"), &plaintext)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.PlaintextWo = types.StringNull()
+	}
+	pubKey, err := parsePublicKey(data.PublicKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Public key is invalid can not be decoded", err.Error())
+		return
+	}
+	ciphertext, err := encryptPayload(pubKey, plaintext.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to encrypt payload", err.Error())
+		return
+	}
+	data.Ciphertext = types.StringValue(string(ciphertext))
+	tflog.Trace(ctx, "created an encrypted payload")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EncryptedPayloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data EncryptedPayloadResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// nothing to refresh, the payload only exists in state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EncryptedPayloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EncryptedPayloadResourceModel
+
+	// all inputs require a replace, so only keep the plan
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.PlaintextWo = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EncryptedPayloadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &FormatRightFunction{}
+
+func NewFormatRightFunction() function.Function {
+	return &FormatRightFunction{}
+}
+
+// FormatRightFunction builds a right_value_pattern from the object returned by parse_right.
+type FormatRightFunction struct{}
+
+func (f *FormatRightFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_right"
+}
+
+func (f *FormatRightFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Format a right_value_pattern",
+		MarkdownDescription: "Build a right_value_pattern f.e.: (rw)VALUES.foo.> from an object like parse_right returns it. target may be null, otherwise it is checked against path.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "right",
+				MarkdownDescription: "Object with target, read, write, delete and path",
+				AttributeTypes:      rightObjectAttrTypes,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FormatRightFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var right rightObjectModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &right))
+	if resp.Error != nil {
+		return
+	}
+
+	directions := ""
+	if right.Read.ValueBool() {
+		directions += "r"
+	}
+	if right.Write.ValueBool() {
+		directions += "w"
+	}
+	if right.Delete.ValueBool() {
+		directions += "d"
+	}
+	if directions == "" {
+		resp.Error = function.NewArgumentFuncError(0, "at least one of read, write or delete have to be true")
+		return
+	}
+	pattern := fmt.Sprintf("(%s)%s", directions, right.Path.ValueString())
+	descriptions, err := client.GetRightDescriptionByString(pattern)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("error by right %s: %s", pattern, err.Error()))
+		return
+	}
+	if !right.Target.IsNull() && string(descriptions[0].Target) != right.Target.ValueString() {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("target %s does not match path %s", right.Target.ValueString(), right.Path.ValueString()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, pattern))
+}
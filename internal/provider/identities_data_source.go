@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSourceWithConfigure = &IdentitiesDataSource{}
+
+func NewIdentitiesDataSource() datasource.DataSource {
+	return &IdentitiesDataSource{}
+}
+
+type IdentitiesDataSource struct {
+	provider *VaultCloudProviderData
+}
+
+type IdentitiesDataSourceModel struct {
+	PrivateKey   types.String              `tfsdk:"private_key"`
+	VaultID      types.String              `tfsdk:"vault_id"`
+	NameRegex    types.String              `tfsdk:"name_regex"`
+	RightsTarget types.String              `tfsdk:"rights_target"`
+	RightsPrefix types.String              `tfsdk:"rights_prefix"`
+	Identities   []IdentitiesItemDataModel `tfsdk:"identities"`
+}
+
+type IdentitiesItemDataModel struct {
+	Id        types.String             `tfsdk:"id"`
+	Name      types.String             `tfsdk:"name"`
+	PublicKey types.String             `tfsdk:"public_key"`
+	Rights    []IdentityRightDataModel `tfsdk:"rights"`
+}
+
+func (d *IdentitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_identities"
+}
+
+func (d *IdentitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List all identities of a vault visible to the reader identity, optional filtered by name and rights",
+
+		Attributes: map[string]schema.Attribute{
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of reader identity (default: private_key of provider)",
+				Description:         "Private Key of reader identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only identities with a name matching this regex",
+				Description:         "Only identities with a name matching this regex",
+				Optional:            true,
+			},
+			"rights_target": schema.StringAttribute{
+				MarkdownDescription: "Only identities with a right for this target (values, identities, system)",
+				Description:         "Only identities with a right for this target (values, identities, system)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(client.RightTargetValues), string(client.RightTargetIdentities), string(client.RightTargetSystem)),
+				},
+			},
+			"rights_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only identities with a right pattern starting with this prefix f.e.: VALUES.foo",
+				Description:         "Only identities with a right pattern starting with this prefix f.e.: VALUES.foo",
+				Optional:            true,
+			},
+			"identities": schema.ListNestedAttribute{
+				MarkdownDescription: "Found identities",
+				Description:         "Found identities",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "id of identity",
+							Description:         "id of identity",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of identity",
+							Description:         "Name of identity",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "Public Key of identity",
+							Description:         "Public Key of identity",
+							Computed:            true,
+						},
+						"rights": schema.ListNestedAttribute{
+							MarkdownDescription: "Rights of identity",
+							Description:         "Rights of identity",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"target": schema.StringAttribute{
+										MarkdownDescription: "Target of right (values, identities, system)",
+										Description:         "Target of right (values, identities, system)",
+										Computed:            true,
+									},
+									"right": schema.StringAttribute{
+										MarkdownDescription: "Direction of right (read, write, delete)",
+										Description:         "Direction of right (read, write, delete)",
+										Computed:            true,
+									},
+									"right_value_pattern": schema.StringAttribute{
+										MarkdownDescription: "Pattern of right f.e.: VALUES.foo.>",
+										Description:         "Pattern of right f.e.: VALUES.foo.>",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *IdentitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.provider = providerData
+}
+
+func (d *IdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IdentitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("name_regex is not a valid regex", err.Error())
+			return
+		}
+	}
+
+	privateKey, vaultID := d.provider.readerKey(data.PrivateKey, data.VaultID)
+	query, err := newVaultQuery(d.provider.Endpoint, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	identities, err := query.Identities(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Identities can not be fetched from API", err.Error())
+		return
+	}
+
+	data.VaultID = vaultID
+	data.Identities = make([]IdentitiesItemDataModel, 0)
+	for _, identity := range identities {
+		name := ""
+		if identity.Name != nil {
+			name = *identity.Name
+		}
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		if !data.RightsTarget.IsNull() || !data.RightsPrefix.IsNull() {
+			hasRight := false
+			for _, v := range identity.Rights {
+				if !data.RightsTarget.IsNull() && string(v.Target) != data.RightsTarget.ValueString() {
+					continue
+				}
+				if !data.RightsPrefix.IsNull() && !strings.HasPrefix(v.RightValuePattern, data.RightsPrefix.ValueString()) {
+					continue
+				}
+				hasRight = true
+			}
+			if !hasRight {
+				continue
+			}
+		}
+
+		item := IdentitiesItemDataModel{
+			Id:        types.StringValue(identity.Id),
+			Name:      types.StringPointerValue(identity.Name),
+			PublicKey: types.StringValue(string(identity.PublicKey)),
+			Rights:    make([]IdentityRightDataModel, 0),
+		}
+		for _, v := range identity.Rights {
+			item.Rights = append(item.Rights, IdentityRightDataModel{
+				Target:            types.StringValue(string(v.Target)),
+				Right:             types.StringValue(string(v.Right)),
+				RightValuePattern: types.StringValue(v.RightValuePattern),
+			})
+		}
+		data.Identities = append(data.Identities, item)
+	}
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	client "github.com/cryptvault-cloud/api"
 	"github.com/cryptvault-cloud/helper"
@@ -19,15 +20,25 @@ func NewIdentityDataSource() datasource.DataSource {
 }
 
 type IdentityDataSource struct {
-	client client.ApiHandler
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
 }
 
 type IdentityDataSourceModel struct {
-	Id         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	PublicKey  types.String `tfsdk:"public_key"`
-	PrivateKey types.String `tfsdk:"private_key"`
-	VaultID    types.String `tfsdk:"vault_id"`
+	Id         types.String             `tfsdk:"id"`
+	Name       types.String             `tfsdk:"name"`
+	PublicKey  types.String             `tfsdk:"public_key"`
+	PrivateKey types.String             `tfsdk:"private_key"`
+	VaultID    types.String             `tfsdk:"vault_id"`
+	Rights     []IdentityRightDataModel `tfsdk:"rights"`
+	CreatedAt  types.String             `tfsdk:"created_at"`
+	UpdatedAt  types.String             `tfsdk:"updated_at"`
+}
+
+type IdentityRightDataModel struct {
+	Target            types.String `tfsdk:"target"`
+	Right             types.String `tfsdk:"right"`
+	RightValuePattern types.String `tfsdk:"right_value_pattern"`
 }
 
 func (d *IdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -37,34 +48,77 @@ func (d *IdentityDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *IdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Load and read an already exist identity over vaultId and identityPrivateKey ",
+		MarkdownDescription: `
+Load and read an already exist identity.
+
+Without id, name or public_key the identity of the private_key is loaded.
+Otherwise the private_key (or the private_key of the provider) is only used as reader key to look up the other identity.
+`,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "id of identity",
-				Description:         "id of identity",
+				MarkdownDescription: "id of identity, to look up an other identity by id",
+				Description:         "id of identity, to look up an other identity by id",
+				Optional:            true,
 				Computed:            true,
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of identity",
-				Description:         "Name of identity",
+				MarkdownDescription: "Name of identity, to look up an other identity by name",
+				Description:         "Name of identity, to look up an other identity by name",
+				Optional:            true,
 				Computed:            true,
 			},
 			"public_key": schema.StringAttribute{
-				MarkdownDescription: "Public Key of identity",
-				Description:         "Public Key of identity",
+				MarkdownDescription: "Public Key of identity, to look up an other identity by public key",
+				Description:         "Public Key of identity, to look up an other identity by public key",
+				Optional:            true,
 				Computed:            true,
 			},
 			"private_key": schema.StringAttribute{
-				MarkdownDescription: "Private Key of identity",
-				Description:         "Private Key of identity",
-				Required:            true,
+				MarkdownDescription: "Private Key of identity (default: private_key of provider)",
+				Description:         "Private Key of identity (default: private_key of provider)",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"vault_id": schema.StringAttribute{
-				MarkdownDescription: "ID of used vault",
-				Description:         "ID of used vault",
-				Required:            true,
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"rights": schema.ListNestedAttribute{
+				MarkdownDescription: "Rights of identity",
+				Description:         "Rights of identity",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Target of right (values, identities, system)",
+							Description:         "Target of right (values, identities, system)",
+							Computed:            true,
+						},
+						"right": schema.StringAttribute{
+							MarkdownDescription: "Direction of right (read, write, delete)",
+							Description:         "Direction of right (read, write, delete)",
+							Computed:            true,
+						},
+						"right_value_pattern": schema.StringAttribute{
+							MarkdownDescription: "Pattern of right f.e.: VALUES.foo.>",
+							Description:         "Pattern of right f.e.: VALUES.foo.>",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of identity (RFC3339)",
+				Description:         "Creation time of identity (RFC3339)",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update time of identity (RFC3339)",
+				Description:         "Last update time of identity (RFC3339)",
+				Computed:            true,
 			},
 		},
 	}
@@ -76,7 +130,7 @@ func (d *IdentityDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, err := getClient(&req)
+	providerData, err := getProviderData(&req)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -87,7 +141,8 @@ func (d *IdentityDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.provider = providerData
 }
 
 func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -100,37 +155,94 @@ func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	private_key, err := helper.GetPrivateKeyFromB64String(data.PrivateKey.ValueString())
+	privateKey, vaultID := d.provider.readerKey(data.PrivateKey, data.VaultID)
+	pApi, err := getProtectedApi(d.client, privateKey, vaultID)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Private key is not an ecdsa.Private key: %s", err.Error()), "")
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
 		return
 	}
-
-	vault_id := data.VaultID.ValueString()
-
-	pApi := d.client.GetProtectedApi(private_key, vault_id)
-	pubToken, err := helper.NewBase64PublicPem(&private_key.PublicKey)
-	if err != nil {
-		resp.Diagnostics.AddError("Public key can not be pemed", err.Error())
-		return
+	vault_id := vaultID.ValueString()
+
+	var token_id string
+	switch {
+	case !data.Id.IsNull():
+		token_id = data.Id.ValueString()
+	case !data.PublicKey.IsNull():
+		token_id, err = helper.Base64PublicPem(data.PublicKey.ValueString()).GetIdentityId(vault_id)
+		if err != nil {
+			resp.Diagnostics.AddError("Identity id can not be generated from public key", err.Error())
+			return
+		}
+	case !data.Name.IsNull():
+		query, err := newVaultQuery(d.provider.Endpoint, privateKey, vaultID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error building connection API", err.Error())
+			return
+		}
+		identities, err := query.Identities(ctx, map[string]interface{}{
+			"name": map[string]interface{}{"eq": data.Name.ValueString()},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Identities can not be fetched from API Name: %s", data.Name.ValueString()), err.Error())
+			return
+		}
+		if len(identities) != 1 {
+			resp.Diagnostics.AddError(fmt.Sprintf("Expected exactly one identity with name %s, found %d", data.Name.ValueString(), len(identities)), "")
+			return
+		}
+		token_id = identities[0].Id
+	default:
+		private_key, err := helper.GetPrivateKeyFromB64String(privateKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Private key is not an ecdsa.Private key: %s", err.Error()), "")
+			return
+		}
+		pubToken, err := helper.NewBase64PublicPem(&private_key.PublicKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Public key can not be pemed", err.Error())
+			return
+		}
+		token_id, err = pubToken.GetIdentityId(vault_id)
+		if err != nil {
+			resp.Diagnostics.AddError("Identity id can not be generated", err.Error())
+			return
+		}
 	}
 
-	token_id, err := pubToken.GetIdentityId(vault_id)
-	if err != nil {
-		resp.Diagnostics.AddError("Identity id can not be generated", err.Error())
-	}
 	identity, err := pApi.GetIdentity(token_id)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Identity can not be fetched from API Id: %s", token_id), err.Error())
 		return
 	}
+	if identity == nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Identity not found Id: %s", token_id), "")
+		return
+	}
 
 	data.Id = types.StringValue(identity.Id)
 	data.PublicKey = types.StringValue(string(identity.PublicKey))
-	data.Name = types.StringValue(*identity.Name)
+	data.Name = types.StringPointerValue(identity.Name)
+	data.VaultID = types.StringValue(vault_id)
+	data.CreatedAt = timeValue(identity.CreatedAt)
+	data.UpdatedAt = timeValue(identity.UpdatedAt)
+	data.Rights = make([]IdentityRightDataModel, 0)
+	for _, v := range identity.Rights {
+		data.Rights = append(data.Rights, IdentityRightDataModel{
+			Target:            types.StringValue(string(v.Target)),
+			Right:             types.StringValue(string(v.Right)),
+			RightValuePattern: types.StringValue(v.RightValuePattern),
+		})
+	}
 
 	tflog.Trace(ctx, "read a data source")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func timeValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
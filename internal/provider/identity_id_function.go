@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IdentityIdFunction{}
+
+func NewIdentityIdFunction() function.Function {
+	return &IdentityIdFunction{}
+}
+
+// IdentityIdFunction calculates the id an identity gets for a public key in a vault.
+type IdentityIdFunction struct{}
+
+func (f *IdentityIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "identity_id"
+}
+
+func (f *IdentityIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Id of an identity by its public key",
+		MarkdownDescription: "Returns the id an identity with this public key has in the vault. The public key can be given in the base64 format of the project, as SPKI PEM or as JWK.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "public_key",
+				MarkdownDescription: "Public key of identity",
+			},
+			function.StringParameter{
+				Name:                "vault_id",
+				MarkdownDescription: "ID of vault",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IdentityIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var publicKey, vaultID string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &publicKey, &vaultID))
+	if resp.Error != nil {
+		return
+	}
+
+	pubKey, err := parsePublicKey(publicKey)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Public key is invalid can not be decoded: "+err.Error())
+		return
+	}
+	b64pub, err := helper.NewBase64PublicPem(pubKey)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	id, err := b64pub.GetIdentityId(vaultID)
+	if err != nil {
+		resp.Error = function.NewFuncError("unable to create id form public key: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, id))
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -12,10 +13,14 @@ import (
 
 	client "github.com/cryptvault-cloud/api"
 	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -25,6 +30,15 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.ResourceWithImportState = &IdentityResource{}
+var _ resource.ResourceWithModifyPlan = &IdentityResource{}
+
+// pendingSyncKey is the private state key marking an identity whose related values
+// were not synced completely after creation.
+const pendingSyncKey = "pending_sync"
+
+type pendingSyncState struct {
+	Pending bool `json:"pending"`
+}
 
 var ValuePatternRegex *regexp.Regexp
 var ValuesPatternRegex *regexp.Regexp
@@ -45,19 +59,31 @@ type IdentityResource struct {
 
 // ExampleResourceModel describes the resource data model.
 type IdentityResourceModel struct {
-	Id          types.String          `tfsdk:"id"`
-	Name        types.String          `tfsdk:"name"`
-	LastUpdated types.String          `tfsdk:"last_updated"`
-	PublicKey   types.String          `tfsdk:"public_key"`
-	VaultID     types.String          `tfsdk:"vault_id"`
-	CreatorKey  types.String          `tfsdk:"creator_key"`
-	Rights      []RightsResourceModel `tfsdk:"rights"`
+	Id                 types.String          `tfsdk:"id"`
+	Name               types.String          `tfsdk:"name"`
+	LastUpdated        types.String          `tfsdk:"last_updated"`
+	PublicKey          types.String          `tfsdk:"public_key"`
+	PublicKeyWo        types.String          `tfsdk:"public_key_wo"`
+	PublicKeyWoVersion types.Int64           `tfsdk:"public_key_wo_version"`
+	VaultID            types.String          `tfsdk:"vault_id"`
+	CreatorKey         types.String          `tfsdk:"creator_key"`
+	Rights             []RightsResourceModel `tfsdk:"rights"`
+	Roles              []RoleReferenceModel  `tfsdk:"roles"`
+	EffectiveRights    types.List            `tfsdk:"effective_rights"`
+	Rollback           types.Bool            `tfsdk:"rollback_on_failure"`
+	SyncConcurrency    types.Int64           `tfsdk:"sync_concurrency"`
 }
 
 type RightsResourceModel struct {
 	RightValuePattern types.String `tfsdk:"right_value_pattern"`
 }
 
+// RoleReferenceModel is a cryptvault_cloud_role given to an identity.
+type RoleReferenceModel struct {
+	Name   types.String          `tfsdk:"name"`
+	Rights []RightsResourceModel `tfsdk:"rights"`
+}
+
 func (r *IdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_identity"
 }
@@ -83,11 +109,33 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 				// },
 			},
 			"public_key": schema.StringAttribute{
-				MarkdownDescription: "Public key of identity",
-				Required:            true,
+				MarkdownDescription: "Public key of identity, exactly one of public_key or public_key_wo have to be set",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("public_key_wo")),
+				},
+			},
+			"public_key_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only public key of identity (Terraform 1.11+), f.e. of an ephemeral cryptvault_cloud_keypair. It is only read on create, public_key holds it afterwards",
+				Optional:            true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("public_key_wo_version")),
+				},
+			},
+			"public_key_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of public_key_wo, a change replaces the identity with one for the current public_key_wo",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("public_key_wo")),
+				},
 			},
 			"vault_id": schema.StringAttribute{
 				Required:            true,
@@ -98,40 +146,59 @@ func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaReques
 				Sensitive:           true,
 				MarkdownDescription: "Private key of identity with rights to create new identities",
 			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: `
+Delete the new identity again if syncing the related values fails during creation.
+Otherwise the identity is kept and the apply fails, the sync is resumed with the next apply.
+Terraform marks an identity with a failed creation as tainted, use terraform untaint to resume the sync instead of recreating it.
+`,
+			},
+			"sync_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Count of values synced in parallel for this identity. Default: %d", defaultSyncConcurrency),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"rights": schema.ListNestedAttribute{
-				Required:            true,
-				MarkdownDescription: "Permissions for this new Identity",
+				Optional:            true,
+				MarkdownDescription: "Permissions for this new Identity, at least one of rights or roles have to be set",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"right_value_pattern": schema.StringAttribute{
-							Required: true,
-							MarkdownDescription: fmt.Sprintf(`
-Path to right point separated. 
-						
-Have to match /%s/
-
-some examples: 
-	- (rwd)VALUES.foo.bar
-	- (rdw)VALUES.foo.>
-	- (rwd)VALUES.>
-	- (w)IDENTITY.>
-	- (r)IDENTITY.>
-	- (rd)VALUES.foo.*
-
-Explain: 
-- r = read
-- w = write
-- d = delete
-- > = same area and deeper (next . split group)
-- * = same area but each possible string
-							`, helper.ValuePatternRegexStr),
-							Validators: []validator.String{
-								stringvalidator.RegexMatches(ValuePatternRegex, "Have to match right string pattern"),
+						"right_value_pattern": rightValuePatternAttribute(),
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("roles")),
+				},
+			},
+			"roles": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Roles of this Identity f.e.: [cryptvault_cloud_role.reader], their rights are added to rights",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of role",
+						},
+						"rights": schema.ListNestedAttribute{
+							Required:            true,
+							MarkdownDescription: "Permissions bundled by the role",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"right_value_pattern": rightValuePatternAttribute(),
+								},
 							},
 						},
 					},
 				},
 			},
+			"effective_rights": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Union of rights and the rights of all roles, as set at the vault",
+			},
 		},
 	}
 }
@@ -189,7 +256,8 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	if len(data.Rights) == 0 {
+	rights := effectiveRights(data)
+	if len(rights) == 0 {
 		resp.Diagnostics.AddError("Minimum one right is required for creating a new Identity", "")
 		return
 	}
@@ -200,12 +268,25 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	rightInputs, err := getRightInputs(data.Rights)
+	rightInputs, err := getRightInputs(rights)
 	if err != nil {
 		resp.Diagnostics.AddError("error by rights convert"+err.Error(), err.Error())
 		return
 	}
+	effective, diags := effectiveRightsValue(rights)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EffectiveRights = effective
 
+	if !data.PublicKeyWoVersion.IsNull() {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &data.PublicKey)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.PublicKeyWo = types.StringNull()
+	}
 	pubKey, err := helper.GetPublicKeyFromB64String(data.PublicKey.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("error convert key to edcsa.publickey: "+err.Error(), err.Error())
@@ -220,25 +301,109 @@ func (r *IdentityResource) Create(ctx context.Context, req resource.CreateReques
 	data.Id = types.StringValue(result.IdentityId)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-	values, err := pAPI.GetAllRelatedValues(data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("error by get all related values for current creating identity", err.Error())
+	// The identity exists from here on, so keep it in state even if the sync fails.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setPendingSync(ctx, resp.Private, true)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	for _, v := range values {
-		err := pAPI.SyncValue(v.Id)
-		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("error by sync value %s for current creating identity", v), err.Error())
+
+	err = syncRelatedValues(ctx, pAPI, data.Id.ValueString(), syncConcurrency(data.SyncConcurrency))
+	if err != nil {
+		if data.Rollback.ValueBool() {
+			rollbackErr := pAPI.DeleteIdentity(data.Id.ValueString())
+			if rollbackErr != nil {
+				resp.Diagnostics.AddError("error by rollback identity after failed sync", errors.Join(err, rollbackErr).Error())
+				return
+			}
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddError("error by sync values for current creating identity, identity was rolled back", err.Error())
+			return
 		}
-	}
-	if resp.Diagnostics.HasError() {
+		// the state keeps the pending_sync marker, the apply still has to fail
+		resp.Diagnostics.AddError("Identity created but syncing related values failed, untaint it to resume the sync with the next apply", err.Error())
 		return
 	}
+	resp.Diagnostics.Append(setPendingSync(ctx, resp.Private, false)...)
 
 	tflog.Trace(ctx, "created a resource")
+}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func setPendingSync(ctx context.Context, private privateState, pending bool) diag.Diagnostics {
+	value, err := json.Marshal(pendingSyncState{Pending: pending})
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("unable to marshal pending sync state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, pendingSyncKey, value)
+}
+
+func hasPendingSync(ctx context.Context, private privateState) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, pendingSyncKey)
+	if diags.HasError() || len(value) == 0 {
+		return false, diags
+	}
+	var state pendingSyncState
+	if err := json.Unmarshal(value, &state); err != nil {
+		diags.AddError("unable to unmarshal pending sync state", err.Error())
+		return false, diags
+	}
+	return state.Pending, diags
+}
+
+// effectiveRights returns rights together with the rights of all roles, each pattern only once.
+func effectiveRights(data IdentityResourceModel) []RightsResourceModel {
+	seen := make(map[string]bool)
+	result := make([]RightsResourceModel, 0, len(data.Rights))
+	add := func(rights []RightsResourceModel) {
+		for _, v := range rights {
+			if !v.RightValuePattern.IsUnknown() {
+				if seen[v.RightValuePattern.ValueString()] {
+					continue
+				}
+				seen[v.RightValuePattern.ValueString()] = true
+			}
+			result = append(result, v)
+		}
+	}
+	add(data.Rights)
+	for _, role := range data.Roles {
+		add(role.Rights)
+	}
+	return result
+}
+
+// effectiveRightsValue returns the patterns of rights as effective_rights, unknown if one of them is unknown.
+func effectiveRightsValue(rights []RightsResourceModel) (types.List, diag.Diagnostics) {
+	patterns := make([]string, 0, len(rights))
+	for _, v := range rights {
+		if v.RightValuePattern.IsUnknown() {
+			return types.ListUnknown(types.StringType), nil
+		}
+		patterns = append(patterns, v.RightValuePattern.ValueString())
+	}
+	return types.ListValueFrom(context.Background(), types.StringType, patterns)
+}
+
+// sameRightPatterns reports whether both lists contain the same patterns, independent of order.
+func sameRightPatterns(a, b []RightsResourceModel) bool {
+	patterns := make(map[string]bool, len(a))
+	for _, v := range a {
+		patterns[v.RightValuePattern.ValueString()] = true
+	}
+	for _, v := range b {
+		if !patterns[v.RightValuePattern.ValueString()] {
+			return false
+		}
+		delete(patterns, v.RightValuePattern.ValueString())
+	}
+	return len(patterns) == 0
 }
 
 func getRightInputs(rights []RightsResourceModel) ([]*client.RightInput, error) {
@@ -300,14 +465,22 @@ func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.Name = types.StringValue(*identityData.Name)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	data.VaultID = types.StringValue(identityData.VaultID)
+	// older states have no effective_rights yet
+	effective, diags := effectiveRightsValue(effectiveRights(data))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EffectiveRights = effective
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data IdentityResourceModel
+	var data, state IdentityResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -318,11 +491,18 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		resp.Diagnostics.AddError("Unable to build protected Api", err.Error())
 		return
 	}
-	rightInputs, err := getRightInputs(data.Rights)
+	rights := effectiveRights(data)
+	rightInputs, err := getRightInputs(rights)
 	if err != nil {
 		resp.Diagnostics.AddError("error by rights convert"+err.Error(), err.Error())
 		return
 	}
+	effective, diags := effectiveRightsValue(rights)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EffectiveRights = effective
 
 	if data.Id.IsUnknown() || data.Id.IsNull() || data.Id.ValueString() == "" {
 		if data.PublicKey.IsNull() || data.PublicKey.IsUnknown() {
@@ -337,8 +517,11 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 		data.Id = types.StringValue(id)
 	}
 
-	_, err = pApi.UpdateIdentity(data.Id.ValueString(), data.Name.ValueString(), rightInputs)
-	if err != nil {
+	// UpdateIdentity replaces all rights of the identity, so only call it if name or rights changed.
+	// Provider only attributes (f.e. sync_concurrency) are never sent to the api.
+	rightsChanged := !sameRightPatterns(rights, effectiveRights(state))
+	if rightsChanged || !data.Name.Equal(state.Name) {
+		_, err = pApi.UpdateIdentity(data.Id.ValueString(), data.Name.ValueString(), rightInputs)
 		if err != nil {
 			resp.Diagnostics.AddError("error by update vault", err.Error())
 			return
@@ -348,6 +531,96 @@ func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateReques
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	pending, diags := hasPendingSync(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// new rights (f.e. by a changed role) can relate new values to the identity
+	if !pending && !rightsChanged {
+		return
+	}
+	resp.Diagnostics.Append(setPendingSync(ctx, resp.Private, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "sync related values", map[string]interface{}{"identity": data.Id.ValueString(), "resumed": pending})
+	err = syncRelatedValues(ctx, pApi, data.Id.ValueString(), syncConcurrency(data.SyncConcurrency))
+	if err != nil {
+		resp.Diagnostics.AddError("Syncing related values failed, sync gets resumed with the next apply", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(setPendingSync(ctx, resp.Private, false)...)
+}
+
+// ModifyPlan plans an update if the sync of related values was not finished after creation.
+// It also plans effective_rights and checks the rights of the creator_key identity, so a denied operation fails on plan.
+func (r *IdentityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state IdentityResourceModel
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.planPermission(state, client.DirectionsDelete)...)
+		return
+	}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw) {
+		effective, diags := effectiveRightsValue(effectiveRights(plan))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_rights"), effective)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planPermission(plan, client.DirectionsWrite)...)
+		return
+	}
+	pending, diags := hasPendingSync(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if pending {
+		resp.Diagnostics.AddWarning("Identity has pending sync of related values", "The sync of related values did not finish after creation and gets resumed with this apply.")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())...)
+	}
+	if pending || !req.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.Append(r.planPermission(plan, client.DirectionsWrite)...)
+	}
+}
+
+// planPermission checks that the creator_key identity is allowed to do direction on the identity.
+// Without a known id or public key (f.e. public_key_wo) the check is skipped.
+func (r *IdentityResource) planPermission(data IdentityResourceModel, direction client.Directions) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.CreatorKey.IsUnknown() || data.VaultID.IsUnknown() || r.client == nil {
+		return diags
+	}
+	id := data.Id.ValueString()
+	if data.Id.IsNull() || data.Id.IsUnknown() {
+		if data.PublicKey.IsNull() || data.PublicKey.IsUnknown() {
+			return diags
+		}
+		var err error
+		id, err = helper.Base64PublicPem(data.PublicKey.ValueString()).GetIdentityId(data.VaultID.ValueString())
+		if err != nil {
+			diags.AddError("unable to create id form public key", err.Error())
+			return diags
+		}
+	}
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		diags.AddError("Unable to build protected Api", err.Error())
+		return diags
+	}
+	return checkPermission(pApi, data.CreatorKey, data.VaultID, direction, identityName(id))
 }
 
 func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -389,3 +662,33 @@ func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteReques
 func (r *IdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// rightValuePatternAttribute returns the schema of a right_value_pattern, shared by identities and roles.
+func rightValuePatternAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required: true,
+		MarkdownDescription: fmt.Sprintf(`
+Path to right point separated. 
+						
+Have to match /%s/
+
+some examples: 
+	- (rwd)VALUES.foo.bar
+	- (rdw)VALUES.foo.>
+	- (rwd)VALUES.>
+	- (w)IDENTITY.>
+	- (r)IDENTITY.>
+	- (rd)VALUES.foo.*
+
+Explain: 
+- r = read
+- w = write
+- d = delete
+- > = same area and deeper (next . split group)
+- * = same area but each possible string
+		`, helper.ValuePatternRegexStr),
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(ValuePatternRegex, "Have to match right string pattern"),
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func rightsOf(patterns ...string) []RightsResourceModel {
+	result := make([]RightsResourceModel, 0, len(patterns))
+	for _, p := range patterns {
+		result = append(result, RightsResourceModel{RightValuePattern: types.StringValue(p)})
+	}
+	return result
+}
+
+func patternsOf(rights []RightsResourceModel) []string {
+	result := make([]string, 0, len(rights))
+	for _, r := range rights {
+		result = append(result, r.RightValuePattern.ValueString())
+	}
+	return result
+}
+
+func TestEffectiveRights(t *testing.T) {
+	tests := []struct {
+		name string
+		data IdentityResourceModel
+		want []string
+	}{
+		{
+			name: "only rights",
+			data: IdentityResourceModel{Rights: rightsOf("(r)VALUES.a.>", "(w)VALUES.b")},
+			want: []string{"(r)VALUES.a.>", "(w)VALUES.b"},
+		},
+		{
+			name: "only roles",
+			data: IdentityResourceModel{Roles: []RoleReferenceModel{
+				{Name: types.StringValue("reader"), Rights: rightsOf("(r)VALUES.>")},
+				{Name: types.StringValue("admin"), Rights: rightsOf("(rw)IDENTITY.>")},
+			}},
+			want: []string{"(r)VALUES.>", "(rw)IDENTITY.>"},
+		},
+		{
+			name: "union without duplicates",
+			data: IdentityResourceModel{
+				Rights: rightsOf("(r)VALUES.a.>", "(w)VALUES.b"),
+				Roles: []RoleReferenceModel{
+					{Name: types.StringValue("reader"), Rights: rightsOf("(r)VALUES.a.>", "(r)VALUES.c")},
+					{Name: types.StringValue("other"), Rights: rightsOf("(r)VALUES.c")},
+				},
+			},
+			want: []string{"(r)VALUES.a.>", "(w)VALUES.b", "(r)VALUES.c"},
+		},
+		{
+			name: "nothing",
+			data: IdentityResourceModel{},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := patternsOf(effectiveRights(tt.data)); !slices.Equal(got, tt.want) {
+				t.Errorf("effectiveRights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveRightsValue(t *testing.T) {
+	value, diags := effectiveRightsValue(rightsOf("(r)VALUES.>", "(w)VALUES.a"))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	got := make([]string, 0)
+	value.ElementsAs(context.Background(), &got, false)
+	if want := []string{"(r)VALUES.>", "(w)VALUES.a"}; !slices.Equal(got, want) {
+		t.Errorf("effectiveRightsValue() = %v, want %v", got, want)
+	}
+
+	unknown := append(rightsOf("(r)VALUES.>"), RightsResourceModel{RightValuePattern: types.StringUnknown()})
+	value, _ = effectiveRightsValue(unknown)
+	if !value.IsUnknown() {
+		t.Errorf("effectiveRightsValue() with unknown pattern = %v, want unknown", value)
+	}
+}
+
+func TestSameRightPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{"equal", []string{"(r)VALUES.a", "(w)VALUES.b"}, []string{"(r)VALUES.a", "(w)VALUES.b"}, true},
+		{"other order", []string{"(r)VALUES.a", "(w)VALUES.b"}, []string{"(w)VALUES.b", "(r)VALUES.a"}, true},
+		{"added", []string{"(r)VALUES.a"}, []string{"(r)VALUES.a", "(w)VALUES.b"}, false},
+		{"removed", []string{"(r)VALUES.a", "(w)VALUES.b"}, []string{"(r)VALUES.a"}, false},
+		{"changed", []string{"(r)VALUES.a"}, []string{"(rw)VALUES.a"}, false},
+		{"both empty", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameRightPatterns(rightsOf(tt.a...), rightsOf(tt.b...)); got != tt.want {
+				t.Errorf("sameRightPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// parsePrivateKey reads a private key in the base64 format of the project or as PEM.
+func parsePrivateKey(key string) (*ecdsa.PrivateKey, error) {
+	if strings.Contains(key, "-----BEGIN") {
+		return parsePrivateKeyPem(key)
+	}
+	return parsePrivateKeyB64(key)
+}
+
+// parsePrivateKeyB64 reads a private key in the base64 format of the project only.
+func parsePrivateKeyB64(key string) (*ecdsa.PrivateKey, error) {
+	privKey, err := helper.GetPrivateKeyFromB64String(strings.TrimSpace(key))
+	if err != nil {
+		return nil, err
+	}
+	return privKey, checkP521(&privKey.PublicKey)
+}
+
+// parsePrivateKeyPem reads a SEC1 (EC PRIVATE KEY) or PKCS#8 (PRIVATE KEY) pem encoded private key.
+// The label is not trusted, helper.EncodePrivateKey writes SEC1 with a PRIVATE KEY label.
+func parsePrivateKeyPem(key string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+	privKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		generic, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if pkcs8Err != nil {
+			return nil, errors.Join(err, pkcs8Err)
+		}
+		var ok bool
+		privKey, ok = generic.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("expected an ecdsa private key, got %T", generic)
+		}
+	}
+	return privKey, checkP521(&privKey.PublicKey)
+}
+
+// checkP521 makes sure the key is on the curve used by the vault.
+func checkP521(key *ecdsa.PublicKey) error {
+	if key.Curve != elliptic.P521() {
+		return fmt.Errorf("key have to be an ECDSA P-521 key, got %s", key.Curve.Params().Name)
+	}
+	return nil
+}
+
+// p521ByteLen is the length of a P-521 coordinate in JWK.
+const p521ByteLen = 66
+
+// ecJWK is a JSON Web Key (RFC 7518) of an elliptic curve key, D is only set for private keys.
+type ecJWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+}
+
+// parsePublicKey reads a public key in the base64 format of the project, as SPKI PEM or as JWK.
+func parsePublicKey(key string) (*ecdsa.PublicKey, error) {
+	var pubKey *ecdsa.PublicKey
+	var err error
+	switch trimmed := strings.TrimSpace(key); {
+	case strings.HasPrefix(trimmed, "{"):
+		pubKey, err = parsePublicKeyJwk(trimmed)
+	case strings.Contains(trimmed, "-----BEGIN"):
+		pubKey, err = helper.DecodePublicKey(trimmed)
+	default:
+		pubKey, err = helper.GetPublicKeyFromB64String(trimmed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pubKey, checkP521(pubKey)
+}
+
+func parsePublicKeyJwk(key string) (*ecdsa.PublicKey, error) {
+	var jwk ecJWK
+	if err := json.Unmarshal([]byte(key), &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "EC" || jwk.Crv != "P-521" {
+		return nil, fmt.Errorf("jwk have to be of kty EC and crv P-521, got %s %s", jwk.Kty, jwk.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != p521ByteLen || len(y) != p521ByteLen {
+		return nil, errors.New("jwk coordinates have a wrong length")
+	}
+	// let ecdh check that the point is on the curve
+	if _, err := ecdh.P521().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P521(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+// publicKeyPem returns the key as SPKI PEM (PUBLIC KEY).
+func publicKeyPem(key *ecdsa.PublicKey) (string, error) {
+	return helper.EncodePublicKey(key)
+}
+
+// privateKeyPkcs8Pem returns the key as PKCS#8 PEM (PRIVATE KEY).
+func privateKeyPkcs8Pem(key *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+func publicKeyJwk(key *ecdsa.PublicKey) (string, error) {
+	return marshalJwk(ecJWK{
+		Kty: "EC",
+		Crv: "P-521",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, p521ByteLen))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, p521ByteLen))),
+	})
+}
+
+func privateKeyJwk(key *ecdsa.PrivateKey) (string, error) {
+	return marshalJwk(ecJWK{
+		Kty: "EC",
+		Crv: "P-521",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, p521ByteLen))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, p521ByteLen))),
+		D:   base64.RawURLEncoding.EncodeToString(key.D.FillBytes(make([]byte, p521ByteLen))),
+	})
+}
+
+func marshalJwk(jwk ecJWK) (string, error) {
+	b, err := json.Marshal(jwk)
+	return string(b), err
+}
+
+// publicKeyFingerprint returns the hex encoded SHA-256 of the DER encoded SPKI,
+// the same as: openssl pkey -pubin -outform DER | sha256sum
+func publicKeyFingerprint(key *ecdsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	privKey, pubKey, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64, err := helper.GetB64FromPublicKey(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := publicKeyPem(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := publicKeyJwk(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privJwk, err := privateKeyJwk(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Der, err := x509.MarshalPKIXPublicKey(&p256.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Pem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: p256Der}))
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"base64", b64, false},
+		{"pem", spki, false},
+		{"jwk", jwk, false},
+		{"private jwk", privJwk, false},
+		{"pem of other curve", p256Pem, true},
+		{"jwk with point not on curve", strings.Replace(jwk, `"x":"`, `"x":"AA`, 1), true},
+		{"garbage", "not a key", true},
+		{"empty", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePublicKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(pubKey) {
+				t.Error("parsePublicKey() returned another key")
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	privKey, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64, err := helper.GetB64FromPrivateKey(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := privateKeyPkcs8Pem(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1Der, err := x509.MarshalECPrivateKey(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1 := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1Der}))
+
+	tests := []struct {
+		name       string
+		key        string
+		wantErr    bool
+		wantB64Err bool
+	}{
+		{"base64", b64, false, false},
+		{"pkcs8 pem", pkcs8, false, true},
+		{"sec1 pem", sec1, false, true},
+		{"garbage", "not a key", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrivateKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(privKey) {
+				t.Error("parsePrivateKey() returned another key")
+			}
+			if _, err := parsePrivateKeyB64(tt.key); (err != nil) != tt.wantB64Err {
+				t.Errorf("parsePrivateKeyB64() error = %v, wantErr %v", err, tt.wantB64Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ ephemeral.EphemeralResource = &KeyPairEphemeralResource{}
+
+// KeyPairEphemeralResource creates a keypair which only lives during a run.
+type KeyPairEphemeralResource struct {
+}
+
+type KeyPairEphemeralResourceModel struct {
+	PublicKey  types.String `tfsdk:"public_key"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
+
+func NewKeyPairEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyPairEphemeralResource{}
+}
+
+func (e *KeyPairEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keypair"
+}
+
+func (e *KeyPairEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Create a new KeyPair locally, which is never stored in plan or state (Terraform 1.10+).
+It P521 elliptic curve Keypair.
+
+Each run creates a new KeyPair, so hand the keys only to write-only arguments guarded by a version,
+f.e. public_key_wo of cryptvault_cloud_identity and a write-only secret of another system.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key of identity",
+				Computed:            true,
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Private key of identity",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *KeyPairEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data KeyPairEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	privKey, pubKey, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
+		return
+	}
+	b64priv, err := helper.GetB64FromPrivateKey(privKey)
+	if err != nil {
+		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
+		return
+	}
+	b64pub, err := helper.GetB64FromPublicKey(pubKey)
+	if err != nil {
+		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
+		return
+	}
+	data.PrivateKey = types.StringValue(b64priv)
+	data.PublicKey = types.StringValue(b64pub)
+	tflog.Trace(ctx, "opened an ephemeral key pair")
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -2,29 +2,42 @@ package provider
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"time"
 
 	client "github.com/cryptvault-cloud/api"
 	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &KeyPairResource{}
+var _ resource.ResourceWithImportState = &KeyPairResource{}
+var _ resource.ResourceWithModifyPlan = &KeyPairResource{}
 
 type KeyPairResource struct {
 	client client.ApiHandler
 }
 
 type KeyPairResourceModel struct {
-	PublicKey   types.String `tfsdk:"public_key"`
-	PrivateKey  types.String `tfsdk:"private_key"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	PublicKey     types.String `tfsdk:"public_key"`
+	PrivateKey    types.String `tfsdk:"private_key"`
+	PrivateKeyPem types.String `tfsdk:"private_key_pem"`
+	LastUpdated   types.String `tfsdk:"last_updated"`
+
+	PublicKeyPem         types.String `tfsdk:"public_key_pem"`
+	PublicKeyJwk         types.String `tfsdk:"public_key_jwk"`
+	PublicKeyFingerprint types.String `tfsdk:"public_key_fingerprint_sha256"`
+	PrivateKeyPkcs8Pem   types.String `tfsdk:"private_key_pkcs8_pem"`
+	PrivateKeyJwk        types.String `tfsdk:"private_key_jwk"`
 }
 
 func NewKeyPairResource() resource.Resource {
@@ -42,7 +55,10 @@ func (r *KeyPairResource) Schema(ctx context.Context, req resource.SchemaRequest
 Create a new KeyPair locally.
 It P521 elliptic curve Keypair.
 You can use them to create an Identity.
-`,
+
+An existing P521 key can be used by private_key (base64) or private_key_pem.
+To import it, use its public key as id and set the private key in the configuration:
+` + "`terraform import cryptvault_cloud_keypair.name <base64 public key>`",
 
 		Attributes: map[string]schema.Attribute{
 			"last_updated": schema.StringAttribute{
@@ -59,7 +75,61 @@ You can use them to create an Identity.
 				},
 			},
 			"private_key": schema.StringAttribute{
-				MarkdownDescription: "Private key of identity",
+				MarkdownDescription: "Private key of identity in base64 format, set it to use an existing key (default: a new generated one)",
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(privateKeyChanged(parsePrivateKeyB64), "Replace if private_key holds an other key", "Replace if private_key holds an other key"),
+				},
+				Validators: []validator.String{
+					privateKeyValidator{},
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				MarkdownDescription: "Existing private key as PEM (SEC1 or PKCS#8) to use instead of a new generated one",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(privateKeyChanged(parsePrivateKeyPem), "Replace if private_key_pem holds an other key", "Replace if private_key_pem holds an other key"),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key")),
+					privateKeyValidator{pem: true},
+				},
+			},
+			"public_key_pem": schema.StringAttribute{
+				MarkdownDescription: "Public key as SPKI PEM (PUBLIC KEY)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_jwk": schema.StringAttribute{
+				MarkdownDescription: "Public key as JSON Web Key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA-256 of the DER encoded SPKI public key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_pkcs8_pem": schema.StringAttribute{
+				MarkdownDescription: "Private key as PKCS#8 PEM (PRIVATE KEY)",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key_jwk": schema.StringAttribute{
+				MarkdownDescription: "Private key as JSON Web Key",
 				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
@@ -99,23 +169,25 @@ func (r *KeyPairResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	privKey, pubKey, err := r.client.GetNewIdentityKeyPair()
-	if err != nil {
-		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
-		return
+	var privKey *ecdsa.PrivateKey
+	var err error
+	switch {
+	case !data.PrivateKeyPem.IsNull():
+		privKey, err = parsePrivateKeyPem(data.PrivateKeyPem.ValueString())
+	case !data.PrivateKey.IsNull() && !data.PrivateKey.IsUnknown():
+		privKey, err = parsePrivateKeyB64(data.PrivateKey.ValueString())
+	default:
+		privKey, _, err = r.client.GetNewIdentityKeyPair()
 	}
-	b64priv, err := helper.GetB64FromPrivateKey(privKey)
 	if err != nil {
 		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
 		return
 	}
-	b64pub, err := helper.GetB64FromPublicKey(pubKey)
+	err = setKeyPairFormats(&data, privKey)
 	if err != nil {
 		resp.Diagnostics.AddError("error by create a new keypair", err.Error())
 		return
 	}
-	data.PrivateKey = types.StringValue(b64priv)
-	data.PublicKey = types.StringValue(b64pub)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	tflog.Trace(ctx, "created a key pair")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -130,17 +202,52 @@ func (r *KeyPairResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// outputs added later are null in older states and UseStateForUnknown keeps them null, so fill them here
+	if data.PrivateKey.IsNull() || !keyPairFormatsMissing(data) {
+		return
+	}
+	privKey, err := parsePrivateKeyB64(data.PrivateKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("private key in state is invalid", err.Error())
+		return
+	}
+	err = setKeyPairFormats(&data, privKey)
+	if err != nil {
+		resp.Diagnostics.AddError("error by encode keypair", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *KeyPairResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data KeyPairResourceModel
 
 	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// the key itself never changes in place, only the outputs are derived again.
+	// An imported keypair has no private_key in state yet, it comes from private_key_pem then.
+	var privKey *ecdsa.PrivateKey
+	var err error
+	if data.PrivateKey.IsNull() || data.PrivateKey.IsUnknown() {
+		privKey, err = parsePrivateKeyPem(data.PrivateKeyPem.ValueString())
+	} else {
+		privKey, err = parsePrivateKeyB64(data.PrivateKey.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("private key is invalid", err.Error())
+		return
+	}
+	err = setKeyPairFormats(&data, privKey)
+	if err != nil {
+		resp.Diagnostics.AddError("error by encode keypair", err.Error())
+		return
+	}
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *KeyPairResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -155,3 +262,128 @@ func (r *KeyPairResource) Delete(ctx context.Context, req resource.DeleteRequest
 	data.PrivateKey = types.StringNull()
 	data.PublicKey = types.StringNull()
 }
+
+// ModifyPlan requires the private key in the configuration of an imported keypair,
+// the import only knows the public key.
+func (r *KeyPairResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state KeyPairResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.PrivateKey.IsNull() && plan.PrivateKey.IsUnknown() && plan.PrivateKeyPem.IsNull() {
+		resp.Diagnostics.AddError(
+			"private_key or private_key_pem is required",
+			fmt.Sprintf("Keypair %s was imported by its public key, set its private key by private_key or private_key_pem.", state.PublicKey.ValueString()),
+		)
+	}
+}
+
+func (r *KeyPairResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pubKey, err := parsePublicKey(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Import id have to be a P521 public key", err.Error())
+		return
+	}
+	data := KeyPairResourceModel{
+		PrivateKey:         types.StringNull(),
+		PrivateKeyPem:      types.StringNull(),
+		PrivateKeyPkcs8Pem: types.StringNull(),
+		PrivateKeyJwk:      types.StringNull(),
+		LastUpdated:        types.StringValue(time.Now().Format(time.RFC850)),
+	}
+	err = setKeyPairPublicFormats(&data, pubKey)
+	if err != nil {
+		resp.Diagnostics.AddError("error by encode public key", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// privateKeyChanged replaces the keypair only if the planned private key, read by parse,
+// belongs to an other public key than the current one.
+func privateKeyChanged(parse func(string) (*ecdsa.PrivateKey, error)) stringplanmodifier.RequiresReplaceIfFunc {
+	return func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+			// removing the key from the configuration keeps it
+			return
+		}
+		var current types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("public_key"), &current)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		privKey, err := parse(req.PlanValue.ValueString())
+		if err != nil {
+			resp.RequiresReplace = true
+			return
+		}
+		b64pub, err := helper.GetB64FromPublicKey(&privKey.PublicKey)
+		resp.RequiresReplace = err != nil || b64pub != current.ValueString()
+	}
+}
+
+// keyPairFormatsMissing reports whether one of the derived outputs is not set.
+func keyPairFormatsMissing(data KeyPairResourceModel) bool {
+	for _, v := range []types.String{data.PublicKey, data.PublicKeyPem, data.PublicKeyJwk, data.PublicKeyFingerprint, data.PrivateKeyPkcs8Pem, data.PrivateKeyJwk} {
+		if v.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// setKeyPairFormats sets all key outputs of privKey, private_key is only set if it is not given.
+func setKeyPairFormats(data *KeyPairResourceModel, privKey *ecdsa.PrivateKey) error {
+	b64priv, err := helper.GetB64FromPrivateKey(privKey)
+	if err != nil {
+		return err
+	}
+	pkcs8, err := privateKeyPkcs8Pem(privKey)
+	if err != nil {
+		return err
+	}
+	privJwk, err := privateKeyJwk(privKey)
+	if err != nil {
+		return err
+	}
+	err = setKeyPairPublicFormats(data, &privKey.PublicKey)
+	if err != nil {
+		return err
+	}
+	if data.PrivateKey.IsNull() || data.PrivateKey.IsUnknown() {
+		data.PrivateKey = types.StringValue(b64priv)
+	}
+	data.PrivateKeyPkcs8Pem = types.StringValue(pkcs8)
+	data.PrivateKeyJwk = types.StringValue(privJwk)
+	return nil
+}
+
+// setKeyPairPublicFormats sets all public key outputs of pubKey.
+func setKeyPairPublicFormats(data *KeyPairResourceModel, pubKey *ecdsa.PublicKey) error {
+	b64pub, err := helper.GetB64FromPublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	spki, err := publicKeyPem(pubKey)
+	if err != nil {
+		return err
+	}
+	pubJwk, err := publicKeyJwk(pubKey)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := publicKeyFingerprint(pubKey)
+	if err != nil {
+		return err
+	}
+	data.PublicKey = types.StringValue(b64pub)
+	data.PublicKeyPem = types.StringValue(spki)
+	data.PublicKeyJwk = types.StringValue(pubJwk)
+	data.PublicKeyFingerprint = types.StringValue(fingerprint)
+	return nil
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &LeastPrivilegeDataSource{}
+
+func NewLeastPrivilegeDataSource() datasource.DataSource {
+	return &LeastPrivilegeDataSource{}
+}
+
+// LeastPrivilegeDataSource computes the smallest set of right_value_patterns covering the needed value names.
+type LeastPrivilegeDataSource struct {
+}
+
+type LeastPrivilegeDataSourceModel struct {
+	Read               []types.String        `tfsdk:"read"`
+	Write              []types.String        `tfsdk:"write"`
+	Delete             []types.String        `tfsdk:"delete"`
+	KnownValues        []types.String        `tfsdk:"known_values"`
+	RightValuePatterns []types.String        `tfsdk:"right_value_patterns"`
+	Rights             []RightsResourceModel `tfsdk:"rights"`
+}
+
+func (d *LeastPrivilegeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_least_privilege"
+}
+
+func (d *LeastPrivilegeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nameValidators := []validator.List{
+		listvalidator.ValueStringsAre(stringvalidator.RegexMatches(client.ValuesPatternRegex, "Have to match value string pattern")),
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Compute the minimal right_value_patterns covering exactly the value names a workload needs.
+
+Names are only collapsed to * or > if all siblings are needed. Siblings are only known by known_values
+(f.e. the names of cryptvault_cloud_value_names), without them every name is kept as it is.
+
+- * = all names of the same area, if all of them are needed and there are at least 2
+- > = all names of the same area and deeper, if all of them are needed and there are at least 2
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"read": schema.ListAttribute{
+				MarkdownDescription: "Value names the workload have to read",
+				Description:         "Value names the workload have to read",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: append([]validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("write"), path.MatchRoot("delete")),
+				}, nameValidators...),
+			},
+			"write": schema.ListAttribute{
+				MarkdownDescription: "Value names the workload have to write",
+				Description:         "Value names the workload have to write",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          nameValidators,
+			},
+			"delete": schema.ListAttribute{
+				MarkdownDescription: "Value names the workload have to delete",
+				Description:         "Value names the workload have to delete",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          nameValidators,
+			},
+			"known_values": schema.ListAttribute{
+				MarkdownDescription: "All existing value names, used to find out if all siblings are needed",
+				Description:         "All existing value names, used to find out if all siblings are needed",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          nameValidators,
+			},
+			"right_value_patterns": schema.ListAttribute{
+				MarkdownDescription: "Minimal right_value_patterns f.e.: (rw)VALUES.app.*",
+				Description:         "Minimal right_value_patterns f.e.: (rw)VALUES.app.*",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"rights": schema.ListNestedAttribute{
+				MarkdownDescription: "right_value_patterns in the format of rights of cryptvault_cloud_identity",
+				Description:         "right_value_patterns in the format of rights of cryptvault_cloud_identity",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"right_value_pattern": schema.StringAttribute{
+							MarkdownDescription: "Pattern of right f.e.: (r)VALUES.foo.>",
+							Description:         "Pattern of right f.e.: (r)VALUES.foo.>",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LeastPrivilegeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LeastPrivilegeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	known := stringValues(data.KnownValues)
+	directions := make(map[string]string)
+	for _, v := range []struct {
+		short string
+		names []types.String
+	}{{"r", data.Read}, {"w", data.Write}, {"d", data.Delete}} {
+		for _, pattern := range minimalPatterns(stringValues(v.names), known) {
+			directions[pattern] += v.short
+		}
+	}
+
+	patterns := make([]string, 0, len(directions))
+	for pattern := range directions {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	data.RightValuePatterns = make([]types.String, 0, len(patterns))
+	data.Rights = make([]RightsResourceModel, 0, len(patterns))
+	for _, pattern := range patterns {
+		right := types.StringValue(fmt.Sprintf("(%s)%s", directions[pattern], pattern))
+		data.RightValuePatterns = append(data.RightValuePatterns, right)
+		data.Rights = append(data.Rights, RightsResourceModel{RightValuePattern: right})
+	}
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{"count": len(patterns)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !v.IsNull() && !v.IsUnknown() {
+			result = append(result, v.ValueString())
+		}
+	}
+	return result
+}
+
+// patternNode is one group of a point separated value name.
+type patternNode struct {
+	children map[string]*patternNode
+	isName   bool
+	needed   bool
+}
+
+func (n *patternNode) insert(name string, needed bool) {
+	node := n
+	for _, group := range strings.Split(name, ".") {
+		child, ok := node.children[group]
+		if !ok {
+			child = &patternNode{children: make(map[string]*patternNode)}
+			node.children[group] = child
+		}
+		node = child
+	}
+	node.isName = true
+	node.needed = node.needed || needed
+}
+
+// descendants returns the count of all names below the node and how many of them are needed.
+func (n *patternNode) descendants() (total int, needed int) {
+	for _, c := range n.children {
+		if c.isName {
+			total++
+			if c.needed {
+				needed++
+			}
+		}
+		t, nd := c.descendants()
+		total += t
+		needed += nd
+	}
+	return total, needed
+}
+
+func (n *patternNode) sortedKeys() []string {
+	keys := make([]string, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// minimalPatterns returns the patterns (without directions) covering exactly the needed names within needed and known.
+// Without known names no siblings are known, so nothing is collapsed.
+func minimalPatterns(needed []string, known []string) []string {
+	if len(known) == 0 {
+		unique := make(map[string]bool)
+		result := make([]string, 0)
+		for _, name := range needed {
+			if !unique[name] {
+				unique[name] = true
+				result = append(result, name)
+			}
+		}
+		sort.Strings(result)
+		return result
+	}
+	root := &patternNode{children: make(map[string]*patternNode)}
+	for _, name := range known {
+		root.insert(name, false)
+	}
+	for _, name := range needed {
+		root.insert(name, true)
+	}
+	result := make([]string, 0)
+	for _, key := range root.sortedKeys() {
+		collapsePatternNode(key, root.children[key], &result)
+	}
+	return result
+}
+
+func collapsePatternNode(prefix string, node *patternNode, result *[]string) {
+	total, needed := node.descendants()
+	if needed == 0 {
+		return
+	}
+	if total == needed && total > 1 {
+		*result = append(*result, prefix+".>")
+		return
+	}
+	leafs, neededLeafs := 0, 0
+	for _, c := range node.children {
+		if c.isName {
+			leafs++
+			if c.needed {
+				neededLeafs++
+			}
+		}
+	}
+	useStar := leafs == neededLeafs && neededLeafs > 1
+	if useStar {
+		*result = append(*result, prefix+".*")
+	}
+	for _, key := range node.sortedKeys() {
+		c := node.children[key]
+		if c.isName && c.needed && !useStar {
+			*result = append(*result, prefix+"."+key)
+		}
+		collapsePatternNode(prefix+"."+key, c, result)
+	}
+}
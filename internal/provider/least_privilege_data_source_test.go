@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMinimalPatterns(t *testing.T) {
+	known := []string{
+		"VALUES.app.prod.db.password",
+		"VALUES.app.prod.db.user",
+		"VALUES.app.prod.api.key",
+		"VALUES.app.prod.api.secret",
+		"VALUES.app.prod.flag",
+		"VALUES.app.dev.db.password",
+		"VALUES.other.x",
+	}
+	tests := []struct {
+		name   string
+		needed []string
+		known  []string
+		want   []string
+	}{
+		{
+			name:   "without known values nothing is collapsed",
+			needed: []string{"VALUES.app.prod.db.user", "VALUES.app.prod.db.password", "VALUES.app.prod.db.user"},
+			want:   []string{"VALUES.app.prod.db.password", "VALUES.app.prod.db.user"},
+		},
+		{
+			name:   "single value",
+			needed: []string{"VALUES.app.prod.db.password"},
+			known:  known,
+			want:   []string{"VALUES.app.prod.db.password"},
+		},
+		{
+			name:   "all siblings and deeper collapse to >",
+			needed: []string{"VALUES.app.prod.db.password", "VALUES.app.prod.db.user", "VALUES.app.prod.api.key"},
+			known:  known,
+			want:   []string{"VALUES.app.prod.api.key", "VALUES.app.prod.db.>"},
+		},
+		{
+			name: "whole area collapses to >",
+			needed: []string{
+				"VALUES.app.prod.db.password",
+				"VALUES.app.prod.db.user",
+				"VALUES.app.prod.api.key",
+				"VALUES.app.prod.api.secret",
+				"VALUES.app.prod.flag",
+			},
+			known: known,
+			want:  []string{"VALUES.app.prod.>"},
+		},
+		{
+			name:   "all direct children but not deeper collapse to *",
+			needed: []string{"VALUES.svc.a", "VALUES.svc.b"},
+			known:  []string{"VALUES.svc.a", "VALUES.svc.b", "VALUES.svc.a.nested"},
+			want:   []string{"VALUES.svc.*"},
+		},
+		{
+			name:   "missing sibling keeps names",
+			needed: []string{"VALUES.svc.a", "VALUES.svc.b"},
+			known:  []string{"VALUES.svc.a", "VALUES.svc.b", "VALUES.svc.c"},
+			want:   []string{"VALUES.svc.a", "VALUES.svc.b"},
+		},
+		{
+			name:   "needed name not in known values",
+			needed: []string{"VALUES.new.value"},
+			known:  known,
+			want:   []string{"VALUES.new.value"},
+		},
+		{
+			name:  "nothing needed",
+			known: known,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := minimalPatterns(tt.needed, tt.known)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("minimalPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollapsePatternNode(t *testing.T) {
+	root := &patternNode{children: make(map[string]*patternNode)}
+	root.insert("VALUES.a.x", true)
+	root.insert("VALUES.a.y", true)
+	root.insert("VALUES.b", false)
+
+	result := make([]string, 0)
+	collapsePatternNode("VALUES", root.children["VALUES"], &result)
+	if want := []string{"VALUES.a.>"}; !slices.Equal(result, want) {
+		t.Errorf("collapsePatternNode() = %v, want %v", result, want)
+	}
+}
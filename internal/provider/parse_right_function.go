@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseRightFunction{}
+
+// rightObjectModel is the object form of a right_value_pattern used by parse_right and format_right.
+type rightObjectModel struct {
+	Target types.String `tfsdk:"target"`
+	Read   types.Bool   `tfsdk:"read"`
+	Write  types.Bool   `tfsdk:"write"`
+	Delete types.Bool   `tfsdk:"delete"`
+	Path   types.String `tfsdk:"path"`
+}
+
+var rightObjectAttrTypes = map[string]attr.Type{
+	"target": types.StringType,
+	"read":   types.BoolType,
+	"write":  types.BoolType,
+	"delete": types.BoolType,
+	"path":   types.StringType,
+}
+
+func NewParseRightFunction() function.Function {
+	return &ParseRightFunction{}
+}
+
+// ParseRightFunction splits a right_value_pattern like client.GetRightDescriptionByString does.
+type ParseRightFunction struct{}
+
+func (f *ParseRightFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_right"
+}
+
+func (f *ParseRightFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a right_value_pattern",
+		MarkdownDescription: `
+Parse a right_value_pattern f.e.: (rw)VALUES.foo.> into an object with
+- target = values, identities or system
+- read, write, delete = granted directions
+- path = pattern without directions f.e.: VALUES.foo.>
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pattern",
+				MarkdownDescription: "right_value_pattern f.e.: (rwd)VALUES.foo.>",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: rightObjectAttrTypes,
+		},
+	}
+}
+
+func (f *ParseRightFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pattern))
+	if resp.Error != nil {
+		return
+	}
+
+	descriptions, err := client.GetRightDescriptionByString(pattern)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("error by right %s: %s", pattern, err.Error()))
+		return
+	}
+	result := rightObjectModel{
+		Read:   types.BoolValue(false),
+		Write:  types.BoolValue(false),
+		Delete: types.BoolValue(false),
+	}
+	for _, v := range descriptions {
+		result.Target = types.StringValue(string(v.Target))
+		result.Path = types.StringValue(v.RightValue)
+		switch v.Right {
+		case client.DirectionsRead:
+			result.Read = types.BoolValue(true)
+		case client.DirectionsWrite:
+			result.Write = types.BoolValue(true)
+		case client.DirectionsDelete:
+			result.Delete = types.BoolValue(true)
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, &result))
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	generateFormatPassword = "password"
+	generateFormatHex      = "hex"
+	generateFormatBase64   = "base64"
+	generateFormatUUID     = "uuid"
+
+	defaultGenerateLength = 32
+
+	generateLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	generateUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	generateNumericChars = "0123456789"
+	generateSpecialChars = "!@#$%&*()-_=+[]{}<>:?"
+)
+
+// GenerateModel describes the generate block of a value.
+type GenerateModel struct {
+	Format            types.String `tfsdk:"format"`
+	Length            types.Int64  `tfsdk:"length"`
+	Lower             types.Bool   `tfsdk:"lower"`
+	Upper             types.Bool   `tfsdk:"upper"`
+	Numeric           types.Bool   `tfsdk:"numeric"`
+	Special           types.Bool   `tfsdk:"special"`
+	MinLower          types.Int64  `tfsdk:"min_lower"`
+	MinUpper          types.Int64  `tfsdk:"min_upper"`
+	MinNumeric        types.Int64  `tfsdk:"min_numeric"`
+	MinSpecial        types.Int64  `tfsdk:"min_special"`
+	OverrideSpecial   types.String `tfsdk:"override_special"`
+	ExcludeCharacters types.String `tfsdk:"exclude_characters"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+}
+
+// generatePassframe creates a new random passframe as described by the generate block.
+func generatePassframe(g GenerateModel) (string, error) {
+	length := defaultGenerateLength
+	if !g.Length.IsNull() {
+		length = int(g.Length.ValueInt64())
+	}
+	if length < 1 {
+		return "", errors.New("length have to be at least 1")
+	}
+
+	switch g.Format.ValueString() {
+	case generateFormatHex:
+		b, err := randomBytes(length)
+		return hex.EncodeToString(b), err
+	case generateFormatBase64:
+		b, err := randomBytes(length)
+		return base64.StdEncoding.EncodeToString(b), err
+	case generateFormatUUID:
+		b, err := randomBytes(16)
+		if err != nil {
+			return "", err
+		}
+		// version 4, variant RFC 4122
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	case "", generateFormatPassword:
+		return generatePassword(g, length)
+	default:
+		return "", fmt.Errorf("unknown format %s", g.Format.ValueString())
+	}
+}
+
+func generatePassword(g GenerateModel, length int) (string, error) {
+	special := generateSpecialChars
+	if !g.OverrideSpecial.IsNull() {
+		special = g.OverrideSpecial.ValueString()
+	}
+	exclude := g.ExcludeCharacters.ValueString()
+	classes := []struct {
+		name    string
+		enabled types.Bool
+		chars   string
+		min     types.Int64
+	}{
+		{"lower", g.Lower, generateLowerChars, g.MinLower},
+		{"upper", g.Upper, generateUpperChars, g.MinUpper},
+		{"numeric", g.Numeric, generateNumericChars, g.MinNumeric},
+		{"special", g.Special, special, g.MinSpecial},
+	}
+
+	// check all min counts before drawing any character
+	minSum := 0
+	for _, c := range classes {
+		minCount := int(c.min.ValueInt64())
+		if minCount < 0 {
+			return "", fmt.Errorf("min_%s have to be at least 0, got %d", c.name, minCount)
+		}
+		if minCount > 0 && !c.enabled.IsNull() && !c.enabled.ValueBool() {
+			return "", fmt.Errorf("min_%s is set but %s is disabled", c.name, c.name)
+		}
+		minSum += minCount
+	}
+	if minSum > length {
+		return "", fmt.Errorf("sum of min counts %d is greater than length %d", minSum, length)
+	}
+
+	result := make([]rune, 0, length)
+	all := ""
+	for _, c := range classes {
+		if !c.enabled.IsNull() && !c.enabled.ValueBool() {
+			continue
+		}
+		chars := removeChars(c.chars, exclude)
+		minCount := int(c.min.ValueInt64())
+		if minCount > 0 && chars == "" {
+			return "", fmt.Errorf("no characters left for min_%s %d", c.name, minCount)
+		}
+		for i := 0; i < minCount; i++ {
+			ch, err := randomChar(chars)
+			if err != nil {
+				return "", err
+			}
+			result = append(result, ch)
+		}
+		all += chars
+	}
+	if all == "" {
+		return "", errors.New("no characters left to generate passframe")
+	}
+	for len(result) < length {
+		ch, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, ch)
+	}
+
+	// shuffle, so the min count characters are not always in front
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+	return string(result), nil
+}
+
+func removeChars(chars, exclude string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(exclude, r) {
+			return -1
+		}
+		return r
+	}, chars)
+}
+
+// randomChar picks a random character (not byte), so non-ASCII characters of override_special stay valid.
+func randomChar(chars string) (rune, error) {
+	runes := []rune(chars)
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(runes))))
+	if err != nil {
+		return 0, err
+	}
+	return runes[i.Int64()], nil
+}
+
+func randomBytes(length int) ([]byte, error) {
+	b := make([]byte, length)
+	_, err := rand.Read(b)
+	return b, err
+}
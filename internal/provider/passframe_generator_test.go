@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGeneratePassframe(t *testing.T) {
+	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	tests := []struct {
+		name    string
+		g       GenerateModel
+		wantErr bool
+		check   func(t *testing.T, passframe string)
+	}{
+		{
+			name: "default password",
+			check: func(t *testing.T, passframe string) {
+				if utf8.RuneCountInString(passframe) != defaultGenerateLength {
+					t.Errorf("length = %d, want %d", utf8.RuneCountInString(passframe), defaultGenerateLength)
+				}
+			},
+		},
+		{
+			name: "hex",
+			g:    GenerateModel{Format: types.StringValue(generateFormatHex), Length: types.Int64Value(8)},
+			check: func(t *testing.T, passframe string) {
+				if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(passframe) {
+					t.Errorf("%q is no hex of 8 bytes", passframe)
+				}
+			},
+		},
+		{
+			name: "uuid",
+			g:    GenerateModel{Format: types.StringValue(generateFormatUUID)},
+			check: func(t *testing.T, passframe string) {
+				if !uuidRegex.MatchString(passframe) {
+					t.Errorf("%q is no uuid v4", passframe)
+				}
+			},
+		},
+		{
+			name: "min counts",
+			g: GenerateModel{
+				Length:     types.Int64Value(6),
+				MinNumeric: types.Int64Value(3),
+				MinUpper:   types.Int64Value(3),
+			},
+			check: func(t *testing.T, passframe string) {
+				if strings.Trim(passframe, generateUpperChars+generateNumericChars) != "" {
+					t.Errorf("%q has other characters than upper and numeric", passframe)
+				}
+			},
+		},
+		{
+			name: "non-ASCII override_special",
+			g: GenerateModel{
+				Length:          types.Int64Value(64),
+				Lower:           types.BoolValue(false),
+				Upper:           types.BoolValue(false),
+				Numeric:         types.BoolValue(false),
+				OverrideSpecial: types.StringValue("äöü€"),
+			},
+			check: func(t *testing.T, passframe string) {
+				if !utf8.ValidString(passframe) {
+					t.Fatalf("%q is no valid UTF-8", passframe)
+				}
+				if utf8.RuneCountInString(passframe) != 64 {
+					t.Errorf("length = %d, want 64", utf8.RuneCountInString(passframe))
+				}
+				if strings.Trim(passframe, "äöü€") != "" {
+					t.Errorf("%q has other characters than override_special", passframe)
+				}
+			},
+		},
+		{
+			name:    "length zero",
+			g:       GenerateModel{Length: types.Int64Value(0)},
+			wantErr: true,
+		},
+		{
+			name:    "min counts greater than length",
+			g:       GenerateModel{Length: types.Int64Value(2), MinLower: types.Int64Value(3)},
+			wantErr: true,
+		},
+		{
+			name:    "negative min count",
+			g:       GenerateModel{MinNumeric: types.Int64Value(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "min count of disabled class",
+			g:       GenerateModel{Special: types.BoolValue(false), MinSpecial: types.Int64Value(1)},
+			wantErr: true,
+		},
+		{
+			name: "no characters left",
+			g: GenerateModel{
+				Lower:   types.BoolValue(false),
+				Upper:   types.BoolValue(false),
+				Numeric: types.BoolValue(false),
+				Special: types.BoolValue(false),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			g:       GenerateModel{Format: types.StringValue("base32")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passframe, err := generatePassframe(tt.g)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generatePassframe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, passframe)
+			}
+		})
+	}
+}
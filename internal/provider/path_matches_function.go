@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &PathMatchesFunction{}
+
+func NewPathMatchesFunction() function.Function {
+	return &PathMatchesFunction{}
+}
+
+// PathMatchesFunction reports whether a value name is covered by a (right) pattern.
+type PathMatchesFunction struct{}
+
+func (f *PathMatchesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "path_matches"
+}
+
+func (f *PathMatchesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check if a pattern covers a value name",
+		MarkdownDescription: `
+Returns true if the value name f.e.: VALUES.a.b.c is covered by the pattern.
+The pattern can be given with or without directions f.e.: (r)VALUES.a.> or VALUES.a.>
+
+- > = same area and deeper (next . split group)
+- * = same area but each possible string
+`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pattern",
+				MarkdownDescription: "Pattern f.e.: VALUES.a.> or right_value_pattern f.e.: (r)VALUES.a.*",
+			},
+			function.StringParameter{
+				Name:                "value_name",
+				MarkdownDescription: "Name of value f.e.: VALUES.a.b.c",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *PathMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern, name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pattern, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	if client.ValuePatternRegex.MatchString(pattern) {
+		descriptions, err := client.GetRightDescriptionByString(pattern)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("error by right %s: %s", pattern, err.Error()))
+			return
+		}
+		pattern = descriptions[0].RightValue
+	}
+	if !valueSelectorRegex.MatchString(pattern) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("pattern %s have to match %s", pattern, valueSelectorRegexStr))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, valuePatternMatches(pattern, name)))
+}
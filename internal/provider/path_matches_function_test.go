@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPathMatchesFunction(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{"plain pattern", "VALUES.a.>", "VALUES.a.b.c", true, false},
+		{"read right", "(r)VALUES.a.>", "VALUES.a.b.c", true, false},
+		{"write delete right", "(wd)VALUES.a.*", "VALUES.a.b", true, false},
+		{"right not matching", "(r)VALUES.a.*", "VALUES.a.b.c", false, false},
+		{"right other prefix", "(r)VALUES.a.>", "VALUES.b.c", false, false},
+		{"invalid pattern", "VALUES..a", "VALUES.a", false, true},
+	}
+	ctx := context.Background()
+	f := NewPathMatchesFunction()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+			f.Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.pattern), types.StringValue(tt.value)}),
+			}, resp)
+			if (resp.Error != nil) != tt.wantErr {
+				t.Fatalf("path_matches(%q, %q) error = %v, wantErr %v", tt.pattern, tt.value, resp.Error, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := resp.Result.Value(); !got.Equal(types.BoolValue(tt.want)) {
+				t.Errorf("path_matches(%q, %q) = %s, want %v", tt.pattern, tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// payloadTagLen is the length of the message tag helper.Encrypt appends.
+const payloadTagLen = 16
+
+// payloadMinLen is the minimal length of a decoded ciphertext of helper.Encrypt for P-521:
+// uncompressed ephemeral public key, aes iv and message tag.
+const payloadMinLen = 1 + 2*p521ByteLen + aes.BlockSize + payloadTagLen
+
+// decryptPayload wraps helper.Decrypt, which panics on a wrong key or a malformed ciphertext
+// instead of returning an error.
+func decryptPayload(privKey *ecdsa.PrivateKey, ciphertext string) (plaintext []byte, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("ciphertext is no valid base64: %w", err)
+	}
+	if len(decoded) < payloadMinLen {
+		return nil, fmt.Errorf("ciphertext is too short, expected at least %d bytes but got %d", payloadMinLen, len(decoded))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			plaintext = nil
+			err = fmt.Errorf("ciphertext can not be decrypted with this key: %v", r)
+		}
+	}()
+	return helper.Decrypt(privKey, ciphertext)
+}
+
+// encryptPayload wraps helper.Encrypt, so a panic of the library ends as error.
+func encryptPayload(pubKey *ecdsa.PublicKey, plaintext string) (ciphertext []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ciphertext = nil
+			err = fmt.Errorf("payload can not be encrypted: %v", r)
+		}
+	}()
+	return helper.Encrypt(pubKey, plaintext)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestDecryptPayload(t *testing.T) {
+	privKey, pubKey, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := encryptPayload(pubKey, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		wantErr    bool
+	}{
+		{"matching key", string(ciphertext), false},
+		{"empty", "", true},
+		{"no base64", "not base64!", true},
+		{"too short", base64.StdEncoding.EncodeToString([]byte{4, 1, 2, 3}), true},
+		{"garbage of valid length", base64.StdEncoding.EncodeToString(make([]byte, payloadMinLen)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, err := decryptPayload(privKey, tt.ciphertext)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decryptPayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(plaintext) != "secret" {
+				t.Errorf("decryptPayload() = %q, want %q", plaintext, "secret")
+			}
+		})
+	}
+
+	t.Run("wrong key", func(t *testing.T) {
+		if _, err := decryptPayload(otherKey, string(ciphertext)); err == nil {
+			t.Error("decryptPayload() with wrong key returned no error")
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// grantedRight is a single right of an identity as the api stores it f.e.: write on VALUES.foo.>
+type grantedRight struct {
+	Target  client.RightTarget
+	Right   client.Directions
+	Pattern string
+}
+
+// grants reports whether the right allows direction on the value or identity name f.e.: VALUES.foo.bar or IDENTITY.<id>
+// The name can also be a pattern f.e.: VALUES.foo.*, then the right have to cover all names of it.
+func (r grantedRight) grants(direction client.Directions, name string) bool {
+	return r.Right == direction && valuePatternCovers(r.Pattern, name)
+}
+
+// String returns the right in the right_value_pattern format f.e.: (w)VALUES.foo.>
+func (r grantedRight) String() string {
+	direction := string(r.Right)
+	if direction != "" {
+		direction = direction[:1]
+	}
+	return fmt.Sprintf("(%s)%s", direction, r.Pattern)
+}
+
+// identityRights returns the rights of the identity with the given id.
+func identityRights(pApi client.ProtectedApiHandler, identityId string) ([]grantedRight, error) {
+	identity, err := pApi.GetIdentity(identityId)
+	if err != nil {
+		return nil, err
+	}
+	rights := make([]grantedRight, 0, len(identity.Rights))
+	for _, v := range identity.Rights {
+		rights = append(rights, grantedRight{Target: v.Target, Right: v.Right, Pattern: v.RightValuePattern})
+	}
+	return rights, nil
+}
+
+// matchingRights returns all rights which allow direction on name.
+func matchingRights(rights []grantedRight, direction client.Directions, name string) []grantedRight {
+	result := make([]grantedRight, 0)
+	for _, r := range rights {
+		if r.grants(direction, name) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// identityName returns the name identity rights are matched against f.e.: IDENTITY.<id>
+func identityName(identityId string) string {
+	return "IDENTITY." + identityId
+}
+
+// checkPermission adds an error if the identity of privateKey is not allowed to do direction on name.
+// If the rights can not be read, only a warning is added, the api decides on apply then.
+func checkPermission(pApi client.ProtectedApiHandler, privateKey basetypes.StringValue, vaultID basetypes.StringValue, direction client.Directions, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	identityId, err := getIdentityId(privateKey, vaultID)
+	if err != nil {
+		diags.AddWarning("Unable to check permissions", err.Error())
+		return diags
+	}
+	rights, err := identityRights(pApi, identityId)
+	if err != nil {
+		diags.AddWarning("Unable to check permissions", fmt.Sprintf("rights of identity %s can not be read: %s", identityId, err.Error()))
+		return diags
+	}
+	if len(matchingRights(rights, direction, name)) > 0 {
+		return diags
+	}
+	granted := make([]string, 0, len(rights))
+	for _, r := range rights {
+		granted = append(granted, r.String())
+	}
+	diags.AddError(
+		fmt.Sprintf("Permission denied: %s on %s", direction, name),
+		fmt.Sprintf("Identity %s has no right to %s %s. Granted rights: %s", identityId, direction, name, strings.Join(granted, ", ")),
+	)
+	return diags
+}
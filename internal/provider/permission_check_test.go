@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	client "github.com/cryptvault-cloud/api"
+)
+
+func TestMatchingRights(t *testing.T) {
+	rights := []grantedRight{
+		{Target: client.RightTargetValues, Right: client.DirectionsRead, Pattern: "VALUES.app.>"},
+		{Target: client.RightTargetValues, Right: client.DirectionsWrite, Pattern: "VALUES.app.prod.*"},
+		{Target: client.RightTargetValues, Right: client.DirectionsDelete, Pattern: "VALUES.app.prod.db"},
+		{Target: client.RightTargetIdentities, Right: client.DirectionsWrite, Pattern: "IDENTITY.>"},
+	}
+	tests := []struct {
+		name      string
+		direction client.Directions
+		value     string
+		want      []string
+	}{
+		{"read below >", client.DirectionsRead, "VALUES.app.prod.db", []string{"(r)VALUES.app.>"}},
+		{"read of > area itself", client.DirectionsRead, "VALUES.app", nil},
+		{"write one group by *", client.DirectionsWrite, "VALUES.app.prod.db", []string{"(w)VALUES.app.prod.*"}},
+		{"write two groups deeper than *", client.DirectionsWrite, "VALUES.app.prod.db.user", nil},
+		{"delete exact", client.DirectionsDelete, "VALUES.app.prod.db", []string{"(d)VALUES.app.prod.db"}},
+		{"delete other name", client.DirectionsDelete, "VALUES.app.prod.api", nil},
+		{"identity", client.DirectionsWrite, identityName("abc"), []string{"(w)IDENTITY.>"}},
+		{"pattern covered by >", client.DirectionsRead, "VALUES.app.prod.*", []string{"(r)VALUES.app.>"}},
+		{"pattern wider than right", client.DirectionsWrite, "VALUES.app.prod.>", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, r := range matchingRights(rights, tt.direction, tt.value) {
+				got = append(got, r.String())
+			}
+			if !slices.Equal(got, append([]string{}, tt.want...)) {
+				t.Errorf("matchingRights(%s, %s) = %v, want %v", tt.direction, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrantedRightString(t *testing.T) {
+	r := grantedRight{Target: client.RightTargetValues, Right: client.DirectionsWrite, Pattern: "VALUES.foo.>"}
+	if got := r.String(); got != "(w)VALUES.foo.>" {
+		t.Errorf("String() = %q, want %q", got, "(w)VALUES.foo.>")
+	}
+}
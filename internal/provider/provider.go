@@ -9,6 +9,8 @@ import (
 
 	client "github.com/cryptvault-cloud/api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +20,8 @@ import (
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &VaultCloud{}
+var _ provider.ProviderWithEphemeralResources = &VaultCloud{}
+var _ provider.ProviderWithFunctions = &VaultCloud{}
 
 // VaultCloud defines the provider implementation.
 type VaultCloud struct {
@@ -29,7 +33,19 @@ type VaultCloud struct {
 
 // VaultCloudProviderModel describes the provider data model.
 type VaultCloudProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint   types.String `tfsdk:"endpoint"`
+	VaultID    types.String `tfsdk:"vault_id"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
+
+// VaultCloudProviderData is handed over to all resources and data sources.
+// VaultID and PrivateKey are the provider wide defaults used by data sources
+// if they do not set an own reader key.
+type VaultCloudProviderData struct {
+	Client     client.ApiHandler
+	Endpoint   string
+	VaultID    types.String
+	PrivateKey types.String
 }
 
 func (p *VaultCloud) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,6 +60,15 @@ func (p *VaultCloud) Schema(ctx context.Context, req provider.SchemaRequest, res
 				MarkdownDescription: "vault endpoint",
 				Optional:            true,
 			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "Default vault id for data sources which do not set an own one",
+				Optional:            true,
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "Default private key of reader identity for data sources which do not set an own one",
+				Optional:            true,
+				Sensitive:           true,
+			},
 		},
 	}
 }
@@ -63,9 +88,15 @@ func (p *VaultCloud) Configure(ctx context.Context, req provider.ConfigureReques
 	}
 
 	// Example client configuration for data sources and resources
-	client := client.NewApi(data.Endpoint.ValueString(), http.DefaultClient)
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &VaultCloudProviderData{
+		Client:     client.NewApi(data.Endpoint.ValueString(), http.DefaultClient),
+		Endpoint:   data.Endpoint.ValueString(),
+		VaultID:    data.VaultID,
+		PrivateKey: data.PrivateKey,
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 func (p *VaultCloud) Resources(ctx context.Context) []func() resource.Resource {
@@ -73,7 +104,10 @@ func (p *VaultCloud) Resources(ctx context.Context) []func() resource.Resource {
 		NewVaultResource,
 		NewIdentityResource,
 		NewValueResource,
+		NewValuesResource,
 		NewKeyPairResource,
+		NewEncryptedPayloadResource,
+		NewRoleResource,
 	}
 }
 
@@ -81,8 +115,32 @@ func (p *VaultCloud) DataSources(ctx context.Context) []func() datasource.DataSo
 	return []func() datasource.DataSource{
 		NewVaultDataSource,
 		NewIdentityDataSource,
+		NewIdentitiesDataSource,
 		NewValueDataSource,
+		NewValuesDataSource,
+		NewValueNamesDataSource,
 		NewPublicKeyDataSource,
+		NewEffectivePermissionsDataSource,
+		NewRightsExplainDataSource,
+		NewLeastPrivilegeDataSource,
+		NewAccessMatrixDataSource,
+	}
+}
+
+func (p *VaultCloud) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewValueEphemeralResource,
+		NewKeyPairEphemeralResource,
+	}
+}
+
+func (p *VaultCloud) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewIdentityIdFunction,
+		NewParseRightFunction,
+		NewFormatRightFunction,
+		NewPathMatchesFunction,
+		NewDecryptWithFunction,
 	}
 }
 
@@ -2,21 +2,33 @@ package provider
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"fmt"
 
 	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ datasource.DataSource = &PublicKeyDataSource{}
+var _ datasource.DataSourceWithConfigure = &PublicKeyDataSource{}
 
 type PublicKeyDataSource struct {
+	provider *VaultCloudProviderData
 }
 
 type PublicKeyDataSourceModel struct {
-	PublicKey types.String `tfsdk:"public_key"`
+	PublicKey            types.String `tfsdk:"public_key"`
+	PublicKeyPem         types.String `tfsdk:"public_key_pem"`
+	PublicKeyJwk         types.String `tfsdk:"public_key_jwk"`
+	PublicKeyFingerprint types.String `tfsdk:"public_key_fingerprint_sha256"`
+	VaultID              types.String `tfsdk:"vault_id"`
+	IdentityID           types.String `tfsdk:"identity_id"`
 }
 
 func NewPublicKeyDataSource() datasource.DataSource {
@@ -38,18 +50,79 @@ As an example:
 A team gives his owner a public key to add them to the cryptvault. 
 
 This can be managed by owner over this data source.
+
+The key can be given in the base64 format of the project, as SPKI PEM or as JWK, all other formats are computed.
 		`,
 
 		Attributes: map[string]schema.Attribute{
 			"public_key": schema.StringAttribute{
-				MarkdownDescription: "Public Key of identity",
-				Description:         "Public Key of identity",
-				Required:            true,
+				MarkdownDescription: "Public Key of identity, exactly one of public_key, public_key_pem or public_key_jwk have to be set. It is always read back in the base64 format of the project",
+				Description:         "Public Key of identity, exactly one of public_key, public_key_pem or public_key_jwk have to be set. It is always read back in the base64 format of the project",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("public_key_pem"), path.MatchRoot("public_key_jwk")),
+					publicKeyValidator{},
+				},
+			},
+			"public_key_pem": schema.StringAttribute{
+				MarkdownDescription: "Public Key as SPKI PEM (PUBLIC KEY)",
+				Description:         "Public Key as SPKI PEM (PUBLIC KEY)",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					publicKeyValidator{},
+				},
+			},
+			"public_key_jwk": schema.StringAttribute{
+				MarkdownDescription: "Public Key as JSON Web Key",
+				Description:         "Public Key as JSON Web Key",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					publicKeyValidator{},
+				},
+			},
+			"public_key_fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA-256 of the DER encoded SPKI public key",
+				Description:         "Hex encoded SHA-256 of the DER encoded SPKI public key",
+				Computed:            true,
+			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of vault the identity_id is calculated for (default: vault_id of provider)",
+				Description:         "ID of vault the identity_id is calculated for (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"identity_id": schema.StringAttribute{
+				MarkdownDescription: "Id the identity of this key has in the vault, null without vault_id",
+				Description:         "Id the identity of this key has in the vault, null without vault_id",
+				Computed:            true,
 			},
 		},
 	}
 }
 
+func (d *PublicKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.provider = providerData
+}
+
 func (d *PublicKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data PublicKeyDataSourceModel
 
@@ -60,14 +133,77 @@ func (d *PublicKeyDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	_, err := helper.GetPublicKeyFromB64String(data.PublicKey.ValueString())
+	var input types.String
+	for _, v := range []types.String{data.PublicKey, data.PublicKeyPem, data.PublicKeyJwk} {
+		if !v.IsNull() {
+			input = v
+		}
+	}
+	pubKey, err := parsePublicKey(input.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Public key is invalid can not be decoded", err.Error())
 		return
 	}
+	resp.Diagnostics.Append(setPublicKeyFormats(&data, pubKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.VaultID.IsNull() && d.provider != nil {
+		data.VaultID = d.provider.VaultID
+	}
+	data.IdentityID = types.StringNull()
+	if !data.VaultID.IsNull() {
+		b64pub, err := helper.NewBase64PublicPem(pubKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Public key is invalid can not be decoded", err.Error())
+			return
+		}
+		id, err := b64pub.GetIdentityId(data.VaultID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("unable to create id form public key", err.Error())
+			return
+		}
+		data.IdentityID = types.StringValue(id)
+	}
 
 	tflog.Trace(ctx, "read a data source")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// setPublicKeyFormats fills all formats of the key which are not given by the config.
+// public_key is always the normalized base64 format of the project, whatever format the input was.
+func setPublicKeyFormats(data *PublicKeyDataSourceModel, pubKey *ecdsa.PublicKey) diag.Diagnostics {
+	var diags diag.Diagnostics
+	b64pub, err := helper.GetB64FromPublicKey(pubKey)
+	if err != nil {
+		diags.AddError("error by encode public key", err.Error())
+		return diags
+	}
+	spki, err := publicKeyPem(pubKey)
+	if err != nil {
+		diags.AddError("error by encode public key as pem", err.Error())
+		return diags
+	}
+	jwk, err := publicKeyJwk(pubKey)
+	if err != nil {
+		diags.AddError("error by encode public key as jwk", err.Error())
+		return diags
+	}
+	fingerprint, err := publicKeyFingerprint(pubKey)
+	if err != nil {
+		diags.AddError("error by create fingerprint of public key", err.Error())
+		return diags
+	}
+	data.PublicKey = types.StringValue(b64pub)
+	if data.PublicKeyPem.IsNull() {
+		data.PublicKeyPem = types.StringValue(spki)
+	}
+	if data.PublicKeyJwk.IsNull() {
+		data.PublicKeyJwk = types.StringValue(jwk)
+	}
+	data.PublicKeyFingerprint = types.StringValue(fingerprint)
+	return diags
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &RightsExplainDataSource{}
+
+func NewRightsExplainDataSource() datasource.DataSource {
+	return &RightsExplainDataSource{}
+}
+
+// RightsExplainDataSource shows how right_value_patterns are expanded before they are send to the api.
+type RightsExplainDataSource struct {
+}
+
+type RightsExplainDataSourceModel struct {
+	Patterns    []types.String            `tfsdk:"patterns"`
+	Rights      []RightsExplainEntryModel `tfsdk:"rights"`
+	Explanation types.String              `tfsdk:"explanation"`
+}
+
+type RightsExplainEntryModel struct {
+	Pattern           types.String `tfsdk:"pattern"`
+	Target            types.String `tfsdk:"target"`
+	Right             types.String `tfsdk:"right"`
+	RightValuePattern types.String `tfsdk:"right_value_pattern"`
+}
+
+func (d *RightsExplainDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_rights_explain"
+}
+
+func (d *RightsExplainDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Explain which single rights (target, direction, pattern) a list of right_value_patterns grants, the same way cryptvault_cloud_identity sends them to the api",
+
+		Attributes: map[string]schema.Attribute{
+			"patterns": schema.ListAttribute{
+				MarkdownDescription: "right_value_patterns f.e.: (rwd)VALUES.foo.>",
+				Description:         "right_value_patterns f.e.: (rwd)VALUES.foo.>",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(ValuePatternRegex, "Have to match right string pattern")),
+				},
+			},
+			"rights": schema.ListNestedAttribute{
+				MarkdownDescription: "Expanded rights, one for each direction of a pattern",
+				Description:         "Expanded rights, one for each direction of a pattern",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"pattern": schema.StringAttribute{
+							MarkdownDescription: "right_value_pattern the right is expanded from",
+							Description:         "right_value_pattern the right is expanded from",
+							Computed:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Target of right (values, identities, system)",
+							Description:         "Target of right (values, identities, system)",
+							Computed:            true,
+						},
+						"right": schema.StringAttribute{
+							MarkdownDescription: "Direction of right (read, write, delete)",
+							Description:         "Direction of right (read, write, delete)",
+							Computed:            true,
+						},
+						"right_value_pattern": schema.StringAttribute{
+							MarkdownDescription: "Pattern of right f.e.: VALUES.foo.>",
+							Description:         "Pattern of right f.e.: VALUES.foo.>",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"explanation": schema.StringAttribute{
+				MarkdownDescription: "Human readable explanation, one line for each pattern",
+				Description:         "Human readable explanation, one line for each pattern",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RightsExplainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RightsExplainDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Rights = make([]RightsExplainEntryModel, 0)
+	lines := make([]string, 0, len(data.Patterns))
+	for _, pattern := range data.Patterns {
+		rightInputs, err := getRightInputs([]RightsResourceModel{{RightValuePattern: pattern}})
+		if err != nil {
+			resp.Diagnostics.AddError("error by rights convert", err.Error())
+			return
+		}
+		directions := make([]string, 0, len(rightInputs))
+		for _, v := range rightInputs {
+			data.Rights = append(data.Rights, RightsExplainEntryModel{
+				Pattern:           pattern,
+				Target:            types.StringValue(string(v.Target)),
+				Right:             types.StringValue(string(v.Right)),
+				RightValuePattern: types.StringValue(v.RightValuePattern),
+			})
+			directions = append(directions, string(v.Right))
+		}
+		if len(rightInputs) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s grants %s on %s", pattern.ValueString(), strings.Join(directions, ", "), explainPattern(rightInputs[0].Target, rightInputs[0].RightValuePattern)))
+	}
+	data.Explanation = types.StringValue(strings.Join(lines, "\n"))
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// explainPattern describes in words which names a pattern without directions matches.
+// f.e.: VALUES.foo.> returns: all values below VALUES.foo (one or more groups deeper)
+func explainPattern(target client.RightTarget, pattern string) string {
+	prefix := strings.TrimSuffix(valuePatternPrefix(pattern), ".")
+	switch {
+	case !strings.ContainsAny(pattern, "*>"):
+		return fmt.Sprintf("only %s", pattern)
+	case pattern == prefix+".>":
+		return fmt.Sprintf("all %s below %s (one or more groups deeper)", target, prefix)
+	case strings.HasSuffix(pattern, ".>"):
+		return fmt.Sprintf("all %s matching %s, each * is exactly one group and > one or more groups deeper", target, pattern)
+	default:
+		return fmt.Sprintf("all %s matching %s, each * is exactly one group", target, pattern)
+	}
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	client "github.com/cryptvault-cloud/api"
+)
+
+func TestExplainPattern(t *testing.T) {
+	tests := []struct {
+		target  client.RightTarget
+		pattern string
+		want    string
+	}{
+		{client.RightTargetValues, "VALUES.foo.bar", "only VALUES.foo.bar"},
+		{client.RightTargetValues, "VALUES.foo.>", "all values below VALUES.foo (one or more groups deeper)"},
+		{client.RightTargetIdentities, "IDENTITY.>", "all identities below IDENTITY (one or more groups deeper)"},
+		{client.RightTargetValues, "VALUES.*.db.>", "all values matching VALUES.*.db.>, each * is exactly one group and > one or more groups deeper"},
+		{client.RightTargetValues, "VALUES.foo.*", "all values matching VALUES.foo.*, each * is exactly one group"},
+	}
+	for _, tt := range tests {
+		if got := explainPattern(tt.target, tt.pattern); got != tt.want {
+			t.Errorf("explainPattern(%s, %q) = %q, want %q", tt.target, tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = durationValidator{}
+var _ validator.String = privateKeyValidator{}
+var _ validator.String = publicKeyValidator{}
+
+// durationValidator validates that a string is a positive duration parsable by time.ParseDuration f.e.: 720h
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration f.e.: 720h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("%s, got: %s", v.Description(ctx), req.ConfigValue.ValueString()))
+	}
+}
+
+// privateKeyValidator validates that a string is an ECDSA P-521 private key in base64 format, or as PEM if pem is set.
+type privateKeyValidator struct {
+	pem bool
+}
+
+func (v privateKeyValidator) Description(ctx context.Context) string {
+	if v.pem {
+		return "value must be an ECDSA P-521 private key as PEM"
+	}
+	return "value must be an ECDSA P-521 private key in base64 format, use private_key_pem for PEM"
+}
+
+func (v privateKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v privateKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	parse := parsePrivateKeyB64
+	if v.pem {
+		parse = parsePrivateKeyPem
+	}
+	if _, err := parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid private key", fmt.Sprintf("%s: %s", v.Description(ctx), err.Error()))
+	}
+}
+
+// publicKeyValidator validates that a string is an ECDSA P-521 public key in base64, PEM or JWK format.
+type publicKeyValidator struct{}
+
+func (v publicKeyValidator) Description(ctx context.Context) string {
+	return "value must be an ECDSA P-521 public key"
+}
+
+func (v publicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v publicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parsePublicKey(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid public key", fmt.Sprintf("%s: %s", v.Description(ctx), err.Error()))
+	}
+}
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	client "github.com/cryptvault-cloud/api"
@@ -9,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/argon2"
 )
 
 var _ datasource.DataSource = &ValueDataSource{}
@@ -22,12 +25,14 @@ type ValueDataSource struct {
 }
 
 type ValueDataSourceModel struct {
-	Id         types.String `tfsdk:"id"`
-	VaultID    types.String `tfsdk:"vault_id"`
-	Name       types.String `tfsdk:"name"`
-	Passframe  types.String `tfsdk:"passframe"`
-	Type       types.String `tfsdk:"type"`
-	CreatorKey types.String `tfsdk:"creator_key"`
+	Id            types.String `tfsdk:"id"`
+	VaultID       types.String `tfsdk:"vault_id"`
+	Name          types.String `tfsdk:"name"`
+	Passframe     types.String `tfsdk:"passframe"`
+	Type          types.String `tfsdk:"type"`
+	CreatorKey    types.String `tfsdk:"creator_key"`
+	OmitPassframe types.Bool   `tfsdk:"omit_passframe"`
+	PassframeHash types.String `tfsdk:"passframe_hash"`
 }
 
 func (d *ValueDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -73,6 +78,17 @@ func (d *ValueDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Required:            true,
 				Sensitive:           true,
 			},
+			"omit_passframe": schema.BoolAttribute{
+				MarkdownDescription: "Do not store the passframe in state, only passframe_hash. Use the ephemeral resource to pass the passframe on",
+				Description:         "Do not store the passframe in state, only passframe_hash. Use the ephemeral resource to pass the passframe on",
+				Optional:            true,
+			},
+			"passframe_hash": schema.StringAttribute{
+				MarkdownDescription: "Versioned Argon2id hash of the passframe, usable to detect changes. Anyone who can read the state can brute-force it, so only omit weak passframes if the state is protected",
+				Description:         "Versioned Argon2id hash of the passframe, usable to detect changes. Anyone who can read the state can brute-force it, so only omit weak passframes if the state is protected",
+				Computed:            true,
+				Sensitive:           true,
+			},
 		},
 	}
 }
@@ -154,8 +170,26 @@ func (d *ValueDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	data.PassframeHash = types.StringValue(passframeHash(data.VaultID.ValueString(), data.Id.ValueString(), data.Passframe.ValueString()))
+	if data.OmitPassframe.ValueBool() {
+		data.Passframe = types.StringNull()
+	}
+
 	tflog.Trace(ctx, "read a data source")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// passframeHashVersion prefixes passframe_hash, a change of the scheme has to use a new version.
+const passframeHashVersion = "v1"
+
+// passframeHash returns a versioned hex Argon2id hash of the passframe. It only serves change detection.
+// The salt is derived from vault and value id because the hash has to be stable between reads,
+// so it only prevents precomputed tables. Argon2id makes guessing expensive, but anyone who can read
+// the state can still brute-force a weak passframe offline.
+func passframeHash(vaultId string, valueId string, passframe string) string {
+	salt := sha256.Sum256([]byte("cryptvault passframe_hash:" + vaultId + ":" + valueId))
+	sum := argon2.IDKey([]byte(passframe), salt[:], 3, 64*1024, 4, 32)
+	return passframeHashVersion + ":" + hex.EncodeToString(sum)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassframeHash(t *testing.T) {
+	base := passframeHash("vault", "value", "secret")
+	if !strings.HasPrefix(base, passframeHashVersion+":") {
+		t.Fatalf("passframeHash() = %s, want prefix %s:", base, passframeHashVersion)
+	}
+	tests := []struct {
+		name      string
+		vaultId   string
+		valueId   string
+		passframe string
+		wantEqual bool
+	}{
+		{"same input", "vault", "value", "secret", true},
+		{"other passframe", "vault", "value", "secret2", false},
+		{"other value", "vault", "value2", "secret", false},
+		{"other vault", "vault2", "value", "secret", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := passframeHash(tt.vaultId, tt.valueId, tt.passframe)
+			if (got == base) != tt.wantEqual {
+				t.Errorf("passframeHash() = %s, base %s, wantEqual %v", got, base, tt.wantEqual)
+			}
+		})
+	}
+}
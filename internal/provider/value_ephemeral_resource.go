@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ValueEphemeralResource{}
+
+func NewValueEphemeralResource() ephemeral.EphemeralResource {
+	return &ValueEphemeralResource{}
+}
+
+// ValueEphemeralResource decrypts a value without storing it in plan or state.
+type ValueEphemeralResource struct {
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
+}
+
+type ValueEphemeralResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	VaultID    types.String `tfsdk:"vault_id"`
+	Name       types.String `tfsdk:"name"`
+	Passframe  types.String `tfsdk:"passframe"`
+	Type       types.String `tfsdk:"type"`
+	CreatorKey types.String `tfsdk:"creator_key"`
+}
+
+func (e *ValueEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_value"
+}
+
+func (e *ValueEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Read a value from vault and decrypt it locally by given creator_key. The passframe is never stored in plan or state (Terraform 1.10+)",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "id of value, exactly one of id or name have to be set",
+				Description:         "id of value, exactly one of id or name have to be set",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of value f.e.: VALUES.foo.bar",
+				Description:         "Name of value f.e.: VALUES.foo.bar",
+				Optional:            true,
+				Computed:            true,
+			},
+			"passframe": schema.StringAttribute{
+				MarkdownDescription: "Decrypted passframe of value",
+				Description:         "Decrypted passframe of value",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type enum of value",
+				Description:         "Type enum of value",
+				Computed:            true,
+			},
+			"creator_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of identity (default: private_key of provider)",
+				Description:         "Private Key of identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *ValueEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderDataEphemeral(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *VaultCloudProviderData, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	e.client = providerData.Client
+	e.provider = providerData
+}
+
+func (e *ValueEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ValueEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, vaultID := e.provider.readerKey(data.CreatorKey, data.VaultID)
+	pApi, err := getProtectedApi(e.client, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+
+	values := make([]client.EncryptenValue, 0)
+	if !data.Id.IsNull() {
+		v, err := pApi.GetValueById(data.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Not Possible to getValue by id", err.Error())
+			return
+		}
+		data.Name = types.StringValue(v.Name)
+		data.Type = types.StringValue(string(v.Type))
+		for _, ev := range v.GetValue() {
+			values = append(values, ev)
+		}
+	} else {
+		v, err := pApi.GetValueByName(data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Not Possible to getValue by name", err.Error())
+			return
+		}
+		data.Id = types.StringValue(v.Id)
+		data.Type = types.StringValue(string(v.Type))
+		for _, ev := range v.GetValue() {
+			values = append(values, ev)
+		}
+	}
+	passframe, err := pApi.GetDecryptedPassframe(values)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to decrypt Value", err.Error())
+		return
+	}
+	data.Passframe = types.StringValue(passframe)
+	data.VaultID = vaultID
+
+	tflog.Trace(ctx, "opened an ephemeral resource")
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
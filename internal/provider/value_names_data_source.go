@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSourceWithConfigure = &ValueNamesDataSource{}
+
+func NewValueNamesDataSource() datasource.DataSource {
+	return &ValueNamesDataSource{}
+}
+
+type ValueNamesDataSource struct {
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
+}
+
+type ValueNamesDataSourceModel struct {
+	VaultID    types.String             `tfsdk:"vault_id"`
+	CreatorKey types.String             `tfsdk:"creator_key"`
+	Pattern    types.String             `tfsdk:"pattern"`
+	Values     []ValueNameItemDataModel `tfsdk:"values"`
+}
+
+type ValueNameItemDataModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (d *ValueNamesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_value_names"
+}
+
+func (d *ValueNamesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "List metadata of all values matching a pattern the creator_key is related to. Passframes are never decrypted.",
+
+		Attributes: map[string]schema.Attribute{
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"creator_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of identity (default: private_key of provider)",
+				Description:         "Private Key of identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "Pattern of values to list f.e.: VALUES.app.prod.> or VALUES.app.*",
+				Description:         "Pattern of values to list f.e.: VALUES.app.prod.> or VALUES.app.*",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(valueSelectorRegex, "Have to match "+valueSelectorRegexStr),
+				},
+			},
+			"values": schema.ListNestedAttribute{
+				MarkdownDescription: "Found values",
+				Description:         "Found values",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Value id",
+							Description:         "Value id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of value f.e.: VALUES.foo.bar",
+							Description:         "Name of value f.e.: VALUES.foo.bar",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type enum of value",
+							Description:         "Type enum of value",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "Creation time of value (RFC3339)",
+							Description:         "Creation time of value (RFC3339)",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "Last update time of value (RFC3339)",
+							Description:         "Last update time of value (RFC3339)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ValueNamesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.provider = providerData
+}
+
+func (d *ValueNamesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ValueNamesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, vaultID := d.provider.readerKey(data.CreatorKey, data.VaultID)
+	pApi, err := getProtectedApi(d.client, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	related, err := listValuesByPattern(pApi, privateKey, vaultID, data.Pattern.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Not Possible to list values", err.Error())
+		return
+	}
+
+	// only metadata is fetched, the encrypted passframes are never downloaded
+	ids := make([]string, 0, len(related))
+	for _, v := range related {
+		ids = append(ids, v.Id)
+	}
+	query, err := newVaultQuery(d.provider.Endpoint, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	queried, err := query.Values(ctx, ids)
+	if err != nil {
+		resp.Diagnostics.AddError("Not Possible to list values", err.Error())
+		return
+	}
+	byId := make(map[string]*queriedValue, len(queried))
+	for _, v := range queried {
+		byId[v.Id] = v
+	}
+
+	data.VaultID = vaultID
+	data.Values = make([]ValueNameItemDataModel, 0)
+	for _, v := range related {
+		item := ValueNameItemDataModel{
+			Id:        types.StringValue(v.Id),
+			Name:      types.StringValue(v.Name),
+			Type:      types.StringNull(),
+			CreatedAt: types.StringNull(),
+			UpdatedAt: types.StringNull(),
+		}
+		if value, ok := byId[v.Id]; ok {
+			item.Type = types.StringValue(string(value.Type))
+			item.CreatedAt = timeValue(value.CreatedAt)
+			item.UpdatedAt = timeValue(value.UpdatedAt)
+		}
+		data.Values = append(data.Values, item)
+	}
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{"count": len(data.Values)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
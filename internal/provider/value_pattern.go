@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+// valueSelectorRegexStr matches a value pattern without directions f.e.: VALUES.foo.>
+const valueSelectorRegexStr = `^(VALUES|IDENTITY|SYSTEM)(\.([\w\-]+|[>\*]{1}))+$`
+
+var valueSelectorRegex = regexp.MustCompile(valueSelectorRegexStr)
+
+// valuePatternMatches reports whether the value name is covered by the point separated pattern.
+//
+// * matches exactly one group, > matches one or more groups and is only allowed as last group.
+// f.e.: VALUES.foo.> matches VALUES.foo.bar and VALUES.foo.bar.baz but not VALUES.foo
+// A name has no wildcards, so it is matched the same way one pattern covers another.
+func valuePatternMatches(pattern, name string) bool {
+	return valuePatternCovers(pattern, name)
+}
+
+// valuePatternCovers reports whether every name matched by pattern is also matched by rightPattern.
+// f.e.: VALUES.foo.> covers VALUES.foo.* but VALUES.foo.* does not cover VALUES.foo.>
+func valuePatternCovers(rightPattern, pattern string) bool {
+	rightParts := strings.Split(rightPattern, ".")
+	patternParts := strings.Split(pattern, ".")
+	for i, r := range rightParts {
+		if r == ">" {
+			return i == len(rightParts)-1 && len(patternParts) > i
+		}
+		if i >= len(patternParts) || patternParts[i] == ">" {
+			return false
+		}
+		if r != "*" && r != patternParts[i] {
+			return false
+		}
+	}
+	return len(rightParts) == len(patternParts)
+}
+
+// valuePatternPrefix returns the groups of the pattern in front of the first wildcard, including the trailing point.
+// f.e.: VALUES.app.prod.> returns VALUES.app.prod.
+func valuePatternPrefix(pattern string) string {
+	prefix := ""
+	for _, p := range strings.Split(pattern, ".") {
+		if p == ">" || p == "*" {
+			return prefix
+		}
+		prefix += p + "."
+	}
+	return prefix
+}
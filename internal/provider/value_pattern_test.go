@@ -0,0 +1,61 @@
+package provider
+
+import "testing"
+
+func TestValuePatternCovers(t *testing.T) {
+	tests := []struct {
+		right   string
+		pattern string
+		want    bool
+	}{
+		{"VALUES.foo.bar", "VALUES.foo.bar", true},
+		{"VALUES.foo.bar", "VALUES.foo.baz", false},
+		{"VALUES.foo.*", "VALUES.foo.bar", true},
+		{"VALUES.foo.*", "VALUES.foo.*", true},
+		{"VALUES.foo.*", "VALUES.foo.bar.baz", false},
+		{"VALUES.foo.*", "VALUES.foo.>", false},
+		{"VALUES.foo.>", "VALUES.foo.bar", true},
+		{"VALUES.foo.>", "VALUES.foo.bar.baz", true},
+		{"VALUES.foo.>", "VALUES.foo.*", true},
+		{"VALUES.foo.>", "VALUES.foo.>", true},
+		{"VALUES.foo.>", "VALUES.foo", false},
+		{"VALUES.foo.bar", "VALUES.foo.*", false},
+		{"VALUES.*.bar", "VALUES.foo.bar", true},
+		{"VALUES.*.bar", "VALUES.*.bar", true},
+		{"VALUES.>", "IDENTITY.abc", false},
+		{"IDENTITY.>", "IDENTITY.abc", true},
+	}
+	for _, tt := range tests {
+		if got := valuePatternCovers(tt.right, tt.pattern); got != tt.want {
+			t.Errorf("valuePatternCovers(%q, %q) = %v, want %v", tt.right, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestValuePatternMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"exact", "VALUES.foo.bar", "VALUES.foo.bar", true},
+		{"trailing > one segment", "VALUES.foo.>", "VALUES.foo.bar", true},
+		{"trailing > many segments", "VALUES.foo.>", "VALUES.foo.bar.baz", true},
+		{"trailing > needs a segment", "VALUES.foo.>", "VALUES.foo", false},
+		{"* one segment", "VALUES.*.bar", "VALUES.foo.bar", true},
+		{"* not zero segments", "VALUES.foo.*", "VALUES.foo", false},
+		{"* not two segments", "VALUES.foo.*", "VALUES.foo.bar.baz", false},
+		{"prefix mismatch", "VALUES.foo.>", "VALUES.other.bar", false},
+		{"other area", "VALUES.>", "IDENTITY.foo", false},
+		{"pattern longer", "VALUES.foo.bar.baz", "VALUES.foo.bar", false},
+		{"pattern shorter", "VALUES.foo", "VALUES.foo.bar", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valuePatternMatches(tt.pattern, tt.value); got != tt.want {
+				t.Errorf("valuePatternMatches(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,20 +5,29 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	client "github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -35,6 +44,7 @@ func init() {
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ValueResource{}
 var _ resource.ResourceWithImportState = &ValueResource{}
+var _ resource.ResourceWithModifyPlan = &ValueResource{}
 
 func NewValueResource() resource.Resource {
 	return &ValueResource{}
@@ -47,13 +57,20 @@ type ValueResource struct {
 
 // ExampleResourceModel describes the resource data model.
 type ValueResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	VaultID     types.String `tfsdk:"vault_id"`
-	LastUpdated types.String `tfsdk:"last_updated"`
-	Name        types.String `tfsdk:"name"`
-	Passframe   types.String `tfsdk:"passframe"`
-	Type        types.String `tfsdk:"type"`
-	CreatorKey  types.String `tfsdk:"creator_key"`
+	Id               types.String `tfsdk:"id"`
+	VaultID          types.String `tfsdk:"vault_id"`
+	LastUpdated      types.String `tfsdk:"last_updated"`
+	Name             types.String `tfsdk:"name"`
+	Passframe        types.String `tfsdk:"passframe"`
+	PassframeWo      types.String `tfsdk:"passframe_wo"`
+	PassframeVersion types.Int64  `tfsdk:"passframe_version"`
+	Generate         types.Object `tfsdk:"generate"`
+	Type             types.String `tfsdk:"type"`
+	CreatorKey       types.String `tfsdk:"creator_key"`
+	RotateOnRevoke   types.Bool   `tfsdk:"rotate_on_revoke"`
+	ReaderIds        types.Set    `tfsdk:"reader_ids"`
+	RotationPeriod   types.String `tfsdk:"rotation_period"`
+	RotatedAt        types.String `tfsdk:"rotated_at"`
 }
 
 func (r *ValueResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -91,9 +108,106 @@ func (r *ValueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"passframe": schema.StringAttribute{
-				MarkdownDescription: "passframe of value",
-				Required:            true,
+				MarkdownDescription: "passframe of value, exactly one of passframe, passframe_wo or generate have to be set",
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("generate"), path.MatchRoot("passframe_wo")),
+				},
+			},
+			"passframe_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only passframe of value (Terraform 1.11+), it is never stored in plan or state. Increase passframe_version to write a new one.",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("passframe_version")),
+				},
+			},
+			"passframe_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of passframe_wo, a change writes the current passframe_wo to the vault",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("passframe_wo")),
+				},
+			},
+			"generate": schema.SingleNestedAttribute{
+				MarkdownDescription: "Generate the passframe inside the provider instead of setting it",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"format": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Format of generated passframe (%s, %s, %s, %s). Default: %s", generateFormatPassword, generateFormatHex, generateFormatBase64, generateFormatUUID, generateFormatPassword),
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(generateFormatPassword, generateFormatHex, generateFormatBase64, generateFormatUUID),
+						},
+					},
+					"length": schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("Count of characters for format password, count of random bytes for hex and base64. Default: %d", defaultGenerateLength),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"lower": schema.BoolAttribute{
+						MarkdownDescription: "Use lower case characters. Default: true",
+						Optional:            true,
+					},
+					"upper": schema.BoolAttribute{
+						MarkdownDescription: "Use upper case characters. Default: true",
+						Optional:            true,
+					},
+					"numeric": schema.BoolAttribute{
+						MarkdownDescription: "Use numeric characters. Default: true",
+						Optional:            true,
+					},
+					"special": schema.BoolAttribute{
+						MarkdownDescription: "Use special characters. Default: true",
+						Optional:            true,
+					},
+					"min_lower": schema.Int64Attribute{
+						MarkdownDescription: "Minimum count of lower case characters",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"min_upper": schema.Int64Attribute{
+						MarkdownDescription: "Minimum count of upper case characters",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"min_numeric": schema.Int64Attribute{
+						MarkdownDescription: "Minimum count of numeric characters",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"min_special": schema.Int64Attribute{
+						MarkdownDescription: "Minimum count of special characters",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"override_special": schema.StringAttribute{
+						MarkdownDescription: "Special characters to use instead of " + generateSpecialChars,
+						Optional:            true,
+					},
+					"exclude_characters": schema.StringAttribute{
+						MarkdownDescription: "Characters never used in the generated passframe",
+						Optional:            true,
+					},
+					"keepers": schema.MapAttribute{
+						MarkdownDescription: "Arbitrary values, a change of them generates a new passframe",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "passframe of value",
@@ -107,6 +221,33 @@ func (r *ValueResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Sensitive:           true,
 				MarkdownDescription: "Private key of identity with rights to create new identities",
 			},
+			"rotate_on_revoke": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: `
+If true, plan detects identities which could read this value at the last write but lost access since then (deleted or rights removed).
+A new passframe is generated and encrypted for the remaining readers only, so generate is required.
+`,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("generate")),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Generate a new passframe if the last one is older than this duration f.e.: 720h. Only with generate, rotation happens on the next apply after the period elapsed.",
+				Validators: []validator.String{
+					durationValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("generate")),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the passframe was generated (RFC3339)",
+			},
+			"reader_ids": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Ids of identities the passframe was encrypted for at the last write",
+			},
 		},
 	}
 }
@@ -154,18 +295,41 @@ func (r *ValueResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	passframe, diags := writeOnlyPassframe(ctx, req.Config, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if passframe == nil {
+		if data.Passframe.IsUnknown() || data.Passframe.IsNull() {
+			resp.Diagnostics.Append(generateValuePassframe(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		} else {
+			data.RotatedAt = types.StringNull()
+		}
+		passframe = data.Passframe.ValueStringPointer()
+	}
+
 	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
 	if err != nil {
 		resp.Diagnostics.AddError("error creating protectedAPI", err.Error())
 		return
 	}
-	valueId, err := pApi.AddValue(data.Name.ValueString(), data.Passframe.ValueString(), client.ValueType(data.Type.ValueString()))
+	valueId, err := pApi.AddValue(data.Name.ValueString(), *passframe, client.ValueType(data.Type.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError("error add value", err.Error())
 		return
 	}
 	data.Id = types.StringValue(valueId)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	readerIds, err := getValueReaderIds(pApi, valueId)
+	if err != nil {
+		resp.Diagnostics.AddError("error by read readers of value", err.Error())
+		return
+	}
+	data.ReaderIds = readerIds
 
 	tflog.Trace(ctx, "created a resource")
 
@@ -198,6 +362,14 @@ func (r *ValueResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if err != nil {
 		resp.Diagnostics.AddError("errory by sync Values", err.Error())
 	}
+	if data.ReaderIds.IsNull() || data.ReaderIds.IsUnknown() {
+		readerIds, err := getValueReaderIds(pApi, data.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("error by read readers of value", err.Error())
+			return
+		}
+		data.ReaderIds = readerIds
+	}
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -213,12 +385,29 @@ func (r *ValueResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	passframe, diags := writeOnlyPassframe(ctx, req.Config, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if passframe == nil {
+		if data.Passframe.IsUnknown() {
+			resp.Diagnostics.Append(generateValuePassframe(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		} else if data.RotatedAt.IsUnknown() {
+			data.RotatedAt = types.StringNull()
+		}
+		passframe = data.Passframe.ValueStringPointer()
+	}
+
 	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to build protected Api", err.Error())
 		return
 	}
-	value, err := pApi.UpdateValue(data.Id.ValueString(), data.Name.ValueString(), data.Passframe.ValueString(), client.ValueType(data.Type.ValueString()))
+	value, err := pApi.UpdateValue(data.Id.ValueString(), data.Name.ValueString(), *passframe, client.ValueType(data.Type.ValueString()))
 	if err != nil {
 		if err != nil {
 			resp.Diagnostics.AddError("error by update vault", err.Error())
@@ -228,9 +417,207 @@ func (r *ValueResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	data.Id = types.StringValue(value)
 	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	readerIds, err := getValueReaderIds(pApi, value)
+	if err != nil {
+		resp.Diagnostics.AddError("error by read readers of value", err.Error())
+		return
+	}
+	data.ReaderIds = readerIds
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ModifyPlan keeps a generated passframe until the generate block changes.
+// It also forces an update of the value if rotate_on_revoke is set and an identity
+// lost access to it since the last write. Read already synced the value at this point,
+// so all identity values left over belong to identities which still have access.
+// Finally the rights of the creator_key identity are checked, so a denied operation fails on plan.
+func (r *ValueResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state ValueResourceModel
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.planPermission(state, client.DirectionsDelete)...)
+		return
+	}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.PassframeVersion.IsNull() {
+		// write-only passframe, nothing of it is kept in plan or state
+		plan.Passframe = types.StringNull()
+		plan.RotatedAt = types.StringNull()
+	}
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planPermission(plan, client.DirectionsWrite)...)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	generated := !plan.Generate.IsNull()
+	if generated && plan.Generate.Equal(state.Generate) && !state.Passframe.IsNull() {
+		plan.Passframe = state.Passframe
+		plan.RotatedAt = state.RotatedAt
+	}
+	if !generated {
+		plan.RotatedAt = types.StringNull()
+	}
+	resp.Diagnostics.Append(planRotation(ctx, &plan)...)
+	resp.Diagnostics.Append(r.planRotateOnRevoke(ctx, &plan, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	if !resp.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.Append(r.planPermission(plan, client.DirectionsWrite)...)
+	}
+}
+
+// planPermission checks that the creator_key identity is allowed to do direction on the value.
+func (r *ValueResource) planPermission(data ValueResourceModel, direction client.Directions) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.CreatorKey.IsUnknown() || data.VaultID.IsUnknown() || data.Name.IsUnknown() || r.client == nil {
+		return diags
+	}
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		diags.AddError("Unable to build protected Api", err.Error())
+		return diags
+	}
+	return checkPermission(pApi, data.CreatorKey, data.VaultID, direction, data.Name.ValueString())
+}
+
+func (r *ValueResource) planRotateOnRevoke(ctx context.Context, plan *ValueResourceModel, state ValueResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !plan.RotateOnRevoke.ValueBool() || state.ReaderIds.IsNull() || plan.CreatorKey.IsUnknown() || plan.VaultID.IsUnknown() || r.client == nil {
+		return diags
+	}
+
+	pApi, err := getProtectedApi(r.client, plan.CreatorKey, plan.VaultID)
+	if err != nil {
+		diags.AddError("Unable to build protected Api", err.Error())
+		return diags
+	}
+	current, err := getValueReaderIds(pApi, state.Id.ValueString())
+	if err != nil {
+		diags.AddError("error by read readers of value", err.Error())
+		return diags
+	}
+	currentIds := make([]string, 0)
+	diags.Append(current.ElementsAs(ctx, &currentIds, false)...)
+	lastIds := make([]string, 0)
+	diags.Append(state.ReaderIds.ElementsAs(ctx, &lastIds, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	revoked := helper.Filter(lastIds, func(id string) bool {
+		return !slices.Contains(currentIds, id)
+	})
+	if len(revoked) == 0 {
+		return diags
+	}
+
+	tflog.Info(ctx, "value was readable by revoked identities", map[string]interface{}{"value": state.Name.ValueString(), "revoked": revoked})
+	// generate a new passframe, the revoked identities may know the current one
+	plan.Passframe = types.StringUnknown()
+	plan.RotatedAt = types.StringUnknown()
+	plan.ReaderIds = types.SetUnknown(types.StringType)
+	plan.LastUpdated = types.StringUnknown()
+	return diags
+}
+
+// planRotation plans a new generated passframe if the rotation period elapsed.
+func planRotation(ctx context.Context, plan *ValueResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Generate.IsNull() || plan.RotationPeriod.IsNull() || plan.RotatedAt.IsNull() || plan.RotatedAt.IsUnknown() {
+		return diags
+	}
+	period, err := time.ParseDuration(plan.RotationPeriod.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("rotation_period"), "Invalid duration", err.Error())
+		return diags
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, plan.RotatedAt.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("rotated_at"), "Invalid time", err.Error())
+		return diags
+	}
+	if time.Now().Before(rotatedAt.Add(period)) {
+		return diags
+	}
+	tflog.Info(ctx, "rotation period elapsed, generate new passframe", map[string]interface{}{"value": plan.Name.ValueString(), "rotated_at": plan.RotatedAt.ValueString()})
+	plan.Passframe = types.StringUnknown()
+	plan.RotatedAt = types.StringUnknown()
+	plan.LastUpdated = types.StringUnknown()
+	return diags
+}
+
+// writeOnlyPassframe returns passframe_wo of the config if the write-only mode is used, otherwise nil.
+// The passframe itself is never stored in plan or state, only passframe_version.
+func writeOnlyPassframe(ctx context.Context, config tfsdk.Config, data *ValueResourceModel) (*string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.PassframeVersion.IsNull() {
+		return nil, diags
+	}
+	var passframe types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("passframe_wo"), &passframe)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if passframe.IsNull() || passframe.IsUnknown() {
+		diags.AddAttributeError(path.Root("passframe_wo"), "passframe_wo is required with passframe_version", "")
+		return nil, diags
+	}
+	data.Passframe = types.StringNull()
+	data.PassframeWo = types.StringNull()
+	data.RotatedAt = types.StringNull()
+	return passframe.ValueStringPointer(), diags
+}
+
+// generateValuePassframe sets a new passframe as described by the generate block.
+func generateValuePassframe(ctx context.Context, data *ValueResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.Generate.IsNull() || data.Generate.IsUnknown() {
+		diags.AddError("passframe or generate is required", "")
+		return diags
+	}
+	var generate GenerateModel
+	diags.Append(data.Generate.As(ctx, &generate, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+	passframe, err := generatePassframe(generate)
+	if err != nil {
+		diags.AddError("error by generate passframe", err.Error())
+		return diags
+	}
+	data.Passframe = types.StringValue(passframe)
+	data.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	return diags
+}
+
+// getValueReaderIds returns the ids of all identities which hold an encrypted passframe of the value.
+func getValueReaderIds(pApi client.ProtectedApiHandler, valueId string) (types.Set, error) {
+	value, err := pApi.GetValueById(valueId)
+	if err != nil {
+		return types.SetNull(types.StringType), err
+	}
+	ids := make([]attr.Value, 0)
+	for _, v := range value.Value {
+		ids = append(ids, types.StringValue(v.IdentityID))
+	}
+	result, diags := types.SetValue(types.StringType, ids)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), errors.New("unable to convert reader ids to set")
+	}
+	return result, nil
+}
+
 func (r *ValueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ValueResourceModel
 
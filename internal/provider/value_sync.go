@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultSyncConcurrency = 4
+
+// syncConcurrency returns the configured sync_concurrency or defaultSyncConcurrency if it is not set.
+func syncConcurrency(v types.Int64) int {
+	if v.IsNull() || v.IsUnknown() {
+		return defaultSyncConcurrency
+	}
+	return int(v.ValueInt64())
+}
+
+// syncProgressInterval is the time between two progress log entries while syncing values.
+const syncProgressInterval = 10 * time.Second
+
+type syncJob struct {
+	id   string
+	name string
+}
+
+// syncRelatedValues encrypts all values the identity has access to for this identity.
+// The values are synced by a pool of concurrency workers, all errors are collected.
+func syncRelatedValues(ctx context.Context, pAPI client.ProtectedApiHandler, identityId string, concurrency int) error {
+	values, err := pAPI.GetAllRelatedValues(identityId)
+	if err != nil {
+		return errors.Join(errors.New("error by get all related values for identity"), err)
+	}
+	jobs := make([]syncJob, 0, len(values))
+	for _, v := range values {
+		jobs = append(jobs, syncJob{id: v.Id, name: v.Name})
+	}
+	return syncValues(ctx, pAPI, jobs, concurrency)
+}
+
+func syncValues(ctx context.Context, pAPI client.ProtectedApiHandler, jobs []syncJob, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	total := len(jobs)
+	tflog.Info(ctx, "start syncing values", map[string]interface{}{"total": total, "concurrency": concurrency})
+
+	var done atomic.Int64
+	var errsMu sync.Mutex
+	var errs error = nil
+
+	queue := make(chan syncJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				err := pAPI.SyncValue(job.id)
+				if err != nil {
+					errsMu.Lock()
+					errs = errors.Join(errs, fmt.Errorf("error by sync value %s: %s", job.name, err.Error()))
+					errsMu.Unlock()
+				}
+				done.Add(1)
+			}
+		}()
+	}
+
+	stopProgress := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(syncProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				tflog.Info(ctx, "syncing values", map[string]interface{}{"done": done.Load(), "total": total})
+			case <-stopProgress:
+				return
+			}
+		}
+	}()
+
+dispatch:
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case queue <- job:
+		}
+	}
+	close(queue)
+	wg.Wait()
+	close(stopProgress)
+
+	tflog.Info(ctx, "finished syncing values", map[string]interface{}{"done": done.Load(), "total": total})
+	if ctx.Err() != nil {
+		errs = errors.Join(errs, fmt.Errorf("sync canceled after %d of %d values: %s", done.Load(), total, ctx.Err().Error()))
+	}
+	return errs
+}
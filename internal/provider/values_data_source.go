@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSourceWithConfigure = &ValuesDataSource{}
+
+func NewValuesDataSource() datasource.DataSource {
+	return &ValuesDataSource{}
+}
+
+type ValuesDataSource struct {
+	client   client.ApiHandler
+	provider *VaultCloudProviderData
+}
+
+type ValuesDataSourceModel struct {
+	VaultID     types.String            `tfsdk:"vault_id"`
+	CreatorKey  types.String            `tfsdk:"creator_key"`
+	Pattern     types.String            `tfsdk:"pattern"`
+	StripPrefix types.Bool              `tfsdk:"strip_prefix"`
+	Values      map[string]types.String `tfsdk:"values"`
+	Ids         map[string]types.String `tfsdk:"ids"`
+}
+
+func (d *ValuesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_values"
+}
+
+func (d *ValuesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Read all values matching a pattern the creator_key has access to and decrypt them locally",
+
+		Attributes: map[string]schema.Attribute{
+			"vault_id": schema.StringAttribute{
+				MarkdownDescription: "ID of used vault (default: vault_id of provider)",
+				Description:         "ID of used vault (default: vault_id of provider)",
+				Optional:            true,
+				Computed:            true,
+			},
+			"creator_key": schema.StringAttribute{
+				MarkdownDescription: "Private Key of identity (default: private_key of provider)",
+				Description:         "Private Key of identity (default: private_key of provider)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: `
+Pattern of values to read f.e.: VALUES.app.prod.> or VALUES.app.*
+
+- > = same area and deeper (next . split group)
+- * = same area but each possible string
+`,
+				Description: "Pattern of values to read f.e.: VALUES.app.prod.> or VALUES.app.*",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(valueSelectorRegex, "Have to match "+valueSelectorRegexStr),
+				},
+			},
+			"strip_prefix": schema.BoolAttribute{
+				MarkdownDescription: "Remove the pattern part in front of the first wildcard from the keys f.e.: VALUES.app.prod.db.password becomes db.password",
+				Description:         "Remove the pattern part in front of the first wildcard from the keys f.e.: VALUES.app.prod.db.password becomes db.password",
+				Optional:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Decrypted passframes by name",
+				Description:         "Decrypted passframes by name",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"ids": schema.MapAttribute{
+				MarkdownDescription: "Value ids by name",
+				Description:         "Value ids by name",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ValuesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, err := getProviderData(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.provider = providerData
+}
+
+func (d *ValuesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ValuesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, vaultID := d.provider.readerKey(data.CreatorKey, data.VaultID)
+	pApi, err := getProtectedApi(d.client, privateKey, vaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building connection API", err.Error())
+		return
+	}
+	related, err := listValuesByPattern(pApi, privateKey, vaultID, data.Pattern.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Not Possible to list values", err.Error())
+		return
+	}
+
+	pattern := data.Pattern.ValueString()
+	prefix := valuePatternPrefix(pattern)
+	data.VaultID = vaultID
+	data.Values = make(map[string]types.String)
+	data.Ids = make(map[string]types.String)
+	for _, v := range related {
+		value, err := pApi.GetValueById(v.Id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Not Possible to getValue %s", v.Name), err.Error())
+			return
+		}
+		values := make([]client.EncryptenValue, 0)
+		for _, ev := range value.GetValue() {
+			values = append(values, ev)
+		}
+		passframe, err := pApi.GetDecryptedPassframe(values)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to decrypt Value %s", v.Name), err.Error())
+			return
+		}
+		key := v.Name
+		if data.StripPrefix.ValueBool() {
+			key = strings.TrimPrefix(key, prefix)
+		}
+		data.Values[key] = types.StringValue(passframe)
+		data.Ids[key] = types.StringValue(v.Id)
+	}
+
+	tflog.Trace(ctx, "read a data source", map[string]interface{}{"count": len(data.Values)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type listedValue struct {
+	Id   string
+	Name string
+}
+
+// listValuesByPattern returns all values related to the identity of privateKey which match the pattern.
+func listValuesByPattern(pApi client.ProtectedApiHandler, privateKey basetypes.StringValue, vaultID basetypes.StringValue, pattern string) ([]listedValue, error) {
+	identityId, err := getIdentityId(privateKey, vaultID)
+	if err != nil {
+		return nil, err
+	}
+	related, err := pApi.GetAllRelatedValues(identityId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]listedValue, 0)
+	for _, v := range related {
+		if valuePatternMatches(pattern, v.Name) {
+			result = append(result, listedValue{Id: v.Id, Name: v.Name})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,390 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	client "github.com/cryptvault-cloud/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// valuesKeyRegexStr matches the name of a value relative to the prefix f.e.: db.password
+const valuesKeyRegexStr = `^[\w\-]+(\.[\w\-]+)*$`
+
+var valuesKeyRegex = regexp.MustCompile(valuesKeyRegexStr)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ValuesResource{}
+
+func NewValuesResource() resource.Resource {
+	return &ValuesResource{}
+}
+
+// ValuesResource manages all values below a common prefix as one resource.
+type ValuesResource struct {
+	client client.ApiHandler
+}
+
+type ValuesResourceModel struct {
+	Id          types.String                       `tfsdk:"id"`
+	VaultID     types.String                       `tfsdk:"vault_id"`
+	LastUpdated types.String                       `tfsdk:"last_updated"`
+	Prefix      types.String                       `tfsdk:"prefix"`
+	CreatorKey  types.String                       `tfsdk:"creator_key"`
+	Exclusive   types.Bool                         `tfsdk:"exclusive"`
+	Values      map[string]ValuesItemResourceModel `tfsdk:"values"`
+	Ids         types.Map                          `tfsdk:"ids"`
+}
+
+type ValuesItemResourceModel struct {
+	Passframe types.String `tfsdk:"passframe"`
+	Type      types.String `tfsdk:"type"`
+}
+
+func (r *ValuesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_values"
+}
+
+func (r *ValuesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manage all values below a common prefix as one resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Prefix of managed values",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vault_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "id of related vault",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"prefix": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "common prefix of all values f.e.: VALUES.app.prod",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(client.ValuesPatternRegex, "Have to match value string pattern"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"creator_key": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Private key of identity with rights to create the values",
+			},
+			"exclusive": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "If true, values below prefix which are not part of values get deleted",
+			},
+			"values": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Values by name relative to prefix f.e.: db.password for VALUES.app.prod.db.password",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(valuesKeyRegex, "Have to match "+valuesKeyRegexStr)),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"passframe": schema.StringAttribute{
+							MarkdownDescription: "passframe of value",
+							Required:            true,
+							Sensitive:           true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type enum of value",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(valueTypeRegex, "Have to match "+valueTypeStrRegex),
+							},
+						},
+					},
+				},
+			},
+			"ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Value ids by name relative to prefix",
+			},
+		},
+	}
+}
+
+func (r *ValuesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, err := getClientRessource(&req)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.: %v", req.ProviderData, err),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ValuesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ValuesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("error creating protectedAPI", err.Error())
+		return
+	}
+
+	ids := make(map[string]string)
+	var errs error = nil
+	for key, v := range data.Values {
+		valueId, err := pApi.AddValue(valuesName(data.Prefix, key), v.Passframe.ValueString(), client.ValueType(v.Type.ValueString()))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error add value %s: %s", key, err.Error()))
+			continue
+		}
+		ids[key] = valueId
+	}
+	data.Id = data.Prefix
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	if errs == nil && data.Exclusive.ValueBool() {
+		errs = r.deleteUnmanaged(pApi, data, ids)
+	}
+	if errs != nil {
+		// keep the created values in state, so they are deleted by the next apply
+		data.Values = filterValues(data.Values, ids)
+		resp.Diagnostics.AddError("error add values", errs.Error())
+	}
+	data.Ids = valuesIdsValue(ids)
+
+	tflog.Trace(ctx, "created a resource", map[string]interface{}{"count": len(ids)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValuesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ValuesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build protected Api", err.Error())
+		return
+	}
+
+	ids := make(map[string]string)
+	resp.Diagnostics.Append(data.Ids.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, id := range ids {
+		valueData, err := pApi.GetValueById(id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Can not read value %s", key), err.Error())
+			return
+		}
+		if valueData == nil {
+			// value was deleted outside of terraform, plan will create it again
+			delete(ids, key)
+			continue
+		}
+		err = pApi.SyncValue(id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("errory by sync value %s", key), err.Error())
+		}
+	}
+	data.Values = filterValues(data.Values, ids)
+	data.Ids = valuesIdsValue(ids)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValuesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ValuesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build protected Api", err.Error())
+		return
+	}
+	ids := make(map[string]string)
+	resp.Diagnostics.Append(state.Ids.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var errs error = nil
+	for key, id := range ids {
+		if _, ok := data.Values[key]; ok {
+			continue
+		}
+		err := pApi.DeleteValue(id)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error delete value %s: %s", key, err.Error()))
+			continue
+		}
+		delete(ids, key)
+	}
+	failed := make(map[string]bool)
+	for key, v := range data.Values {
+		id, ok := ids[key]
+		if !ok {
+			valueId, err := pApi.AddValue(valuesName(data.Prefix, key), v.Passframe.ValueString(), client.ValueType(v.Type.ValueString()))
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("error add value %s: %s", key, err.Error()))
+				continue
+			}
+			ids[key] = valueId
+			continue
+		}
+		old := state.Values[key]
+		if old.Passframe.Equal(v.Passframe) && old.Type.Equal(v.Type) {
+			continue
+		}
+		_, err := pApi.UpdateValue(id, valuesName(data.Prefix, key), v.Passframe.ValueString(), client.ValueType(v.Type.ValueString()))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error update value %s: %s", key, err.Error()))
+			failed[key] = true
+		}
+	}
+	if errs == nil && data.Exclusive.ValueBool() {
+		errs = r.deleteUnmanaged(pApi, data, ids)
+	}
+	if errs != nil {
+		resp.Diagnostics.AddError("error update values", errs.Error())
+		// keep the state of values which could not be changed
+		values := make(map[string]ValuesItemResourceModel)
+		for key := range ids {
+			if v, ok := data.Values[key]; ok && !failed[key] {
+				values[key] = v
+			} else {
+				values[key] = state.Values[key]
+			}
+		}
+		data.Values = values
+	}
+
+	data.Ids = valuesIdsValue(ids)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValuesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ValuesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pApi, err := getProtectedApi(r.client, data.CreatorKey, data.VaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build protected Api", err.Error())
+		return
+	}
+
+	ids := make(map[string]string)
+	resp.Diagnostics.Append(data.Ids.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, id := range ids {
+		err = pApi.DeleteValue(id)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete Value %s", key), err.Error())
+		}
+	}
+}
+
+// deleteUnmanaged deletes all values below prefix the creator key has access to but which are not managed by this resource.
+func (r *ValuesResource) deleteUnmanaged(pApi client.ProtectedApiHandler, data ValuesResourceModel, ids map[string]string) error {
+	listed, err := listValuesByPattern(pApi, data.CreatorKey, data.VaultID, data.Prefix.ValueString()+".>")
+	if err != nil {
+		return errors.Join(errors.New("error by list values below prefix"), err)
+	}
+	managed := make(map[string]bool)
+	for _, id := range ids {
+		managed[id] = true
+	}
+	var errs error = nil
+	for _, v := range listed {
+		if managed[v.Id] {
+			continue
+		}
+		err := pApi.DeleteValue(v.Id)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("error delete unmanaged value %s: %s", v.Name, err.Error()))
+		}
+	}
+	return errs
+}
+
+func valuesName(prefix types.String, key string) string {
+	return prefix.ValueString() + "." + key
+}
+
+func filterValues(values map[string]ValuesItemResourceModel, ids map[string]string) map[string]ValuesItemResourceModel {
+	result := make(map[string]ValuesItemResourceModel)
+	for key, v := range values {
+		if _, ok := ids[key]; ok {
+			result[key] = v
+		}
+	}
+	return result
+}
+
+func valuesIdsValue(ids map[string]string) basetypes.MapValue {
+	elements := make(map[string]attr.Value)
+	for key, id := range ids {
+		elements[key] = types.StringValue(id)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	client "github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// vaultQuery runs graphql queries against a vault which are not covered by the client library.
+// The client library only returns a reduced view of identities (f.e. without id and public key).
+type vaultQuery struct {
+	client  graphql.Client
+	vaultId string
+}
+
+type queriedIdentity struct {
+	Id         string                 `json:"id"`
+	Name       *string                `json:"name"`
+	PublicKey  helper.Base64PublicPem `json:"publicKey"`
+	VaultID    string                 `json:"vaultID"`
+	IsOperator bool                   `json:"isOperator"`
+	CreatedAt  *time.Time             `json:"createdAt"`
+	UpdatedAt  *time.Time             `json:"updatedAt"`
+	Rights     []*queriedRight        `json:"rights"`
+}
+
+type queriedRight struct {
+	Target            client.RightTarget `json:"target"`
+	Right             client.Directions  `json:"right"`
+	RightValuePattern string             `json:"rightValuePattern"`
+}
+
+type queriedValue struct {
+	Id        string           `json:"id"`
+	Name      string           `json:"name"`
+	Type      client.ValueType `json:"type"`
+	CreatedAt *time.Time       `json:"createdAt"`
+	UpdatedAt *time.Time       `json:"updatedAt"`
+}
+
+// queryPageSize is the count of entities fetched with one request.
+const queryPageSize = 100
+
+// queryPage is one page of a query result f.e.: of queryIdentity.
+type queryPage[T any] struct {
+	Count      int  `json:"count"`
+	TotalCount int  `json:"totalCount"`
+	Data       []*T `json:"data"`
+}
+
+const queryIdentitiesOperation = `
+query queryIdentities($filter: IdentityFiltersInput, $order: IdentityOrder, $first: Int, $offset: Int) {
+  queryIdentity(filter: $filter, order: $order, first: $first, offset: $offset) {
+    count
+    totalCount
+    data {
+      id
+      name
+      publicKey
+      vaultID
+      isOperator
+      createdAt
+      updatedAt
+      rights {
+        target
+        right
+        rightValuePattern
+      }
+    }
+  }
+}
+`
+
+// queryValuesOperation only selects metadata, the encrypted passframes are not downloaded.
+const queryValuesOperation = `
+query queryValues($filter: ValueFiltersInput, $order: ValueOrder, $first: Int, $offset: Int) {
+  queryValue(filter: $filter, order: $order, first: $first, offset: $offset) {
+    count
+    totalCount
+    data {
+      id
+      name
+      type
+      createdAt
+      updatedAt
+    }
+  }
+}
+`
+
+// authedTransport signs each request with the identity key, like the client library does for the protected api.
+// It is a copy of the transport of the client library, which neither exports it nor the graphql client it builds.
+type authedTransport struct {
+	wrapped http.RoundTripper
+	key     *ecdsa.PrivateKey
+	vaultId string
+}
+
+func (t *authedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := helper.SignJWT(t.key, t.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	return t.wrapped.RoundTrip(req)
+}
+
+func newVaultQuery(endpoint string, privateKey basetypes.StringValue, vaultID basetypes.StringValue) (*vaultQuery, error) {
+	if privateKey.IsNull() {
+		return nil, errors.New("private not allowed to be null")
+	}
+	private_key, err := helper.GetPrivateKeyFromB64String(privateKey.ValueString())
+	if err != nil {
+		return nil, errors.Join(errors.New("private key is not an ecdsa.Private key"), err)
+	}
+	if vaultID.IsNull() {
+		return nil, errors.New("vaultid not allowed to be null")
+	}
+	h := http.Client{
+		Transport: &authedTransport{wrapped: http.DefaultTransport, key: private_key, vaultId: vaultID.ValueString()},
+	}
+	return &vaultQuery{
+		client:  graphql.NewClient(endpoint, &h),
+		vaultId: vaultID.ValueString(),
+	}, nil
+}
+
+// Identities returns all identities of the vault matching the given IdentityFiltersInput.
+func (q *vaultQuery) Identities(ctx context.Context, filter map[string]interface{}) ([]*queriedIdentity, error) {
+	if filter == nil {
+		filter = map[string]interface{}{}
+	}
+	filter["vaultID"] = map[string]interface{}{"eq": q.vaultId}
+	return queryAll[queriedIdentity](ctx, q, "queryIdentities", queryIdentitiesOperation, "queryIdentity", filter)
+}
+
+// Values returns the metadata of the values with the given ids.
+func (q *vaultQuery) Values(ctx context.Context, ids []string) ([]*queriedValue, error) {
+	result := make([]*queriedValue, 0, len(ids))
+	// the ids are sent in chunks to keep the requests small
+	for start := 0; start < len(ids); start += queryPageSize {
+		chunk := ids[start:min(start+queryPageSize, len(ids))]
+		filter := map[string]interface{}{
+			"vaultID": map[string]interface{}{"eq": q.vaultId},
+			"id":      map[string]interface{}{"in": chunk},
+		}
+		values, err := queryAll[queriedValue](ctx, q, "queryValues", queryValuesOperation, "queryValue", filter)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, values...)
+	}
+	return result, nil
+}
+
+// queryAll fetches page by page (ordered by id) until totalCount of the query field is reached.
+func queryAll[T any](ctx context.Context, q *vaultQuery, opName string, query string, field string, filter map[string]interface{}) ([]*T, error) {
+	result := make([]*T, 0)
+	for {
+		data := make(map[string]*queryPage[T])
+		err := q.client.MakeRequest(ctx, &graphql.Request{
+			OpName: opName,
+			Query:  query,
+			Variables: map[string]interface{}{
+				"filter": filter,
+				"order":  map[string]interface{}{"asc": "id"},
+				"first":  queryPageSize,
+				"offset": len(result),
+			},
+		}, &graphql.Response{Data: &data})
+		if err != nil {
+			return nil, err
+		}
+		page := data[field]
+		if page == nil {
+			return result, nil
+		}
+		result = append(result, page.Data...)
+		// an empty page ends the loop, even if totalCount changed in between
+		if len(page.Data) == 0 || len(result) >= page.TotalCount {
+			return result, nil
+		}
+	}
+}
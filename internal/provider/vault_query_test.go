@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cryptvault-cloud/helper"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVaultQueryIdentitiesPaging(t *testing.T) {
+	const total = 2*queryPageSize + 7
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Variables struct {
+				First  int `json:"first"`
+				Offset int `json:"offset"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		data := make([]map[string]interface{}, 0)
+		for i := body.Variables.Offset; i < total && i < body.Variables.Offset+body.Variables.First; i++ {
+			data = append(data, map[string]interface{}{"id": fmt.Sprintf("id-%03d", i), "publicKey": "", "vaultID": "vault"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"queryIdentity": map[string]interface{}{"count": len(data), "totalCount": total, "data": data},
+			},
+		})
+	}))
+	defer server.Close()
+
+	query := newTestVaultQuery(t, server.URL)
+	identities, err := query.Identities(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(identities) != total {
+		t.Errorf("Identities() returned %d identities, want %d", len(identities), total)
+	}
+	if requests != 3 {
+		t.Errorf("Identities() made %d requests, want 3", requests)
+	}
+	if identities[total-1].Id != fmt.Sprintf("id-%03d", total-1) {
+		t.Errorf("last identity = %s", identities[total-1].Id)
+	}
+}
+
+func TestVaultQueryValuesMetadata(t *testing.T) {
+	ids := make([]string, 0)
+	for i := 0; i < queryPageSize+1; i++ {
+		ids = append(ids, fmt.Sprintf("value-%03d", i))
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Filter struct {
+					Id struct {
+						In []string `json:"in"`
+					} `json:"id"`
+				} `json:"filter"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		if strings.Contains(body.Query, "passframe") {
+			t.Error("metadata query selects passframe")
+		}
+		data := make([]map[string]interface{}, 0)
+		for _, id := range body.Variables.Filter.Id.In {
+			data = append(data, map[string]interface{}{"id": id, "name": "VALUES." + id, "type": "String"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"queryValue": map[string]interface{}{"count": len(data), "totalCount": len(data), "data": data},
+			},
+		})
+	}))
+	defer server.Close()
+
+	values, err := newTestVaultQuery(t, server.URL).Values(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(ids) {
+		t.Errorf("Values() returned %d values, want %d", len(values), len(ids))
+	}
+	if requests != 2 {
+		t.Errorf("Values() made %d requests, want 2", requests)
+	}
+}
+
+func newTestVaultQuery(t *testing.T, endpoint string) *vaultQuery {
+	t.Helper()
+	privKey, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64, err := helper.GetB64FromPrivateKey(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := newVaultQuery(endpoint, types.StringValue(b64), types.StringValue("vault"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query
+}